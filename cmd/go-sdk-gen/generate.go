@@ -1,8 +1,10 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"go/version"
 	"log/slog"
 	"os"
 	"os/exec"
@@ -14,13 +16,21 @@ import (
 	"github.com/sumup/go-sdk-gen/pkg/builder"
 )
 
+// minGoVersion is the minimum Go version required by the generated SDK. Older versions
+// ignore the `omitzero` struct tag option and would encode unset nullable fields as null.
+const minGoVersion = "1.24"
+
 func Generate() *cli.Command {
 	var (
-		out     string
-		modName string
-		pkgName string
-		name    string
-		force   bool
+		out           string
+		modName       string
+		pkgName       string
+		name          string
+		baseURL       string
+		envVar        string
+		versionHeader string
+		userAgent     string
+		force         bool
 	)
 
 	return &cli.Command{
@@ -44,10 +54,14 @@ func Generate() *cli.Command {
 			}
 
 			builder := builder.New(builder.Config{
-				Out:     out,
-				Module:  modName,
-				PkgName: pkgName,
-				Name:    name,
+				Out:           out,
+				Module:        modName,
+				PkgName:       pkgName,
+				Name:          name,
+				BaseURL:       baseURL,
+				EnvVar:        envVar,
+				VersionHeader: versionHeader,
+				UserAgent:     userAgent,
 			})
 
 			if err := builder.Load(spec); err != nil {
@@ -78,6 +92,10 @@ func Generate() *cli.Command {
 
 			slog.Info("running post-generate tasks")
 
+			if err := requireGoVersion(out); err != nil {
+				return fmt.Errorf("require go %s: %w", minGoVersion, err)
+			}
+
 			cmd := exec.Command("goimports", "-w", ".")
 			cmd.Dir = out
 			if err := cmd.Run(); err != nil {
@@ -112,10 +130,29 @@ func Generate() *cli.Command {
 			&cli.StringFlag{
 				Name:        "name",
 				Aliases:     []string{"n"},
-				Usage:       "name of your service",
-				Required:    true,
+				Usage:       "name of your service, defaults to the title of the API",
 				Destination: &name,
 			},
+			&cli.StringFlag{
+				Name:        "base-url",
+				Usage:       "default base URL of the client, defaults to the URL of the first server of the API, required if the API has no servers",
+				Destination: &baseURL,
+			},
+			&cli.StringFlag{
+				Name:        "env-var",
+				Usage:       "name of the environment variable the client reads the API key from, defaults to <PACKAGE>_API_KEY",
+				Destination: &envVar,
+			},
+			&cli.StringFlag{
+				Name:        "version-header",
+				Usage:       "name of the header the SDK version is sent in (e.g. SumUp-Version), the header is not sent if empty",
+				Destination: &versionHeader,
+			},
+			&cli.StringFlag{
+				Name:        "user-agent",
+				Usage:       "product name sent in the User-Agent header followed by the SDK version, defaults to the package name",
+				Destination: &userAgent,
+			},
 			&cli.BoolFlag{
 				Name:        "force",
 				Aliases:     []string{"f"},
@@ -125,3 +162,37 @@ func Generate() *cli.Command {
 		},
 	}
 }
+
+// requireGoVersion raises the go directive of the module in the directory dir to
+// [minGoVersion], if it is lower. Directories without a go.mod file are left intact.
+func requireGoVersion(dir string) error {
+	if _, err := os.Stat(path.Join(dir, "go.mod")); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	cmd := exec.Command("go", "mod", "edit", "-json")
+	cmd.Dir = dir
+	data, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("read go.mod: %w", err)
+	}
+
+	var mod struct {
+		Go string
+	}
+	if err := json.Unmarshal(data, &mod); err != nil {
+		return fmt.Errorf("decode go.mod: %w", err)
+	}
+
+	if mod.Go != "" && version.Compare("go"+mod.Go, "go"+minGoVersion) >= 0 {
+		return nil
+	}
+
+	cmd = exec.Command("go", "mod", "edit", "-go="+minGoVersion)
+	cmd.Dir = dir
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("update go directive: %w", err)
+	}
+
+	return nil
+}
@@ -12,12 +12,26 @@ type Client struct {
 	Pets *pets.PetsService
 }
 
-// NewClient creates new SumUp API client.
-// The client is by default configured environment variables (`SUMUP_API_KEY`).
-// To override the default configuration use [ClientOption]s.
+// NewClient creates new Petstore API client.
+// To override the default configuration use [ClientOption]s. Options that fail are skipped,
+// use [NewClientWithError] to handle the errors of the options.
 func NewClient(opts ...client.ClientOption) *Client {
-	client := client.New(opts...)
+	return newClient(client.New(opts...))
+}
+
+// NewClientWithError creates new Petstore API client, same as [NewClient], but returns
+// an error if any of the options fails.
+func NewClientWithError(opts ...client.ClientOption) (*Client, error) {
+	client, err := client.NewWithError(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(client), nil
+}
 
+// newClient creates the API services using the client.
+func newClient(client *client.Client) *Client {
 	c := &Client{c: client}
 	c.Pets = pets.NewPetsService(client)
 
@@ -6,17 +6,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"os"
 	"strings"
 )
 
 const (
-	// APIUrl is the URL of our API.
-	APIUrl = "https://api.sumup.com"
+	// APIUrl is the URL of the Petstore API, used as the default base URL of the client.
+	APIUrl = "http://petstore.swagger.io/v1"
 )
 
 type client interface {
@@ -29,41 +29,67 @@ type Client struct {
 	client *http.Client
 	// base is the base url of the API the requests will be sent to.
 	base *url.URL
+	// customBase is true if the base URL was set using [WithBaseURL], in which case
+	// the requests are sent to the base URL instead of the servers of the operations.
+	customBase bool
+	// serverVariables are the values of the variables of the servers of the operations,
+	// see [WithServerVariables].
+	serverVariables map[string]string
 	// userAgent is the user-agent header that will be sent with
 	// every request.
 	userAgent string
-	// key is the API key or access token used for authorization.
-	key string
+	// credentials are the credentials used for authorization, by the name
+	// of the security scheme.
+	credentials map[string]credential
+	// retryPolicy is the policy for retrying failed requests, nil if requests
+	// are not retried.
+	retryPolicy *RetryPolicy
+	// cache stores the responses for conditional requests, nil if responses
+	// are not cached.
+	cache CacheStore
 }
 
 // ClientOption is an option for the Petstore API client.
 type ClientOption func(c *Client) error
 
 // New creates new HTTP API client.
-// The client is by default configured with environment variables (e.g. `SUMUP_API_KEY`).
-// To override the default configuration use [ClientOption]s.
+// To override the default configuration use [ClientOption]s. Options that fail, e.g. if
+// [WithServer] is given an invalid server variable, are skipped. Use [NewWithError] to
+// handle the errors of the options.
 func New(opts ...ClientOption) *Client {
-	baseURL, _ := url.Parse(APIUrl)
-	c := &Client{
-		client:    http.DefaultClient,
-		userAgent: fmt.Sprintf("petstore/%s", version),
-		base:      baseURL,
-		key:       os.Getenv("SUMUP_API_KEY"),
+	c := newClient()
+	for _, o := range opts {
+		_ = o(c)
 	}
 
+	return c
+}
+
+// NewWithError creates new HTTP API client, same as [New], but returns an error
+// if any of the options fails.
+func NewWithError(opts ...ClientOption) (*Client, error) {
+	c := newClient()
 	for _, o := range opts {
-		o(c)
+		if err := o(c); err != nil {
+			return nil, fmt.Errorf("configure client: %w", err)
+		}
 	}
 
-	return c
+	return c, nil
 }
 
-// WithAPIKey returns a [ClientOption] that configures the client with an API key for authorization.
-func WithAPIKey(key string) ClientOption {
-	return func(c *Client) error {
-		c.key = key
-		return nil
+// newClient returns a client with the default configuration.
+func newClient() *Client {
+	baseURL, _ := url.Parse(APIUrl)
+	c := &Client{
+		client:          http.DefaultClient,
+		userAgent:       "petstore/" + version,
+		base:            baseURL,
+		credentials:     make(map[string]credential),
+		serverVariables: make(map[string]string),
 	}
+
+	return c
 }
 
 // WithClient returns a [ClientOption] that configures the client to use a specific http client
@@ -75,8 +101,18 @@ func WithClient(client *http.Client) ClientOption {
 	}
 }
 
+// WithUserAgent returns a [ClientOption] that configures the client to send the User-Agent
+// header userAgent instead of the default `petstore/<version>`.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
 // WithBaseURL returns a [ClientOption] that configures the client with a base URL that the
-// individual API call paths will be resolved against.
+// individual API call paths will be resolved against. The requests of the operations that
+// override the servers of the API are sent to the base URL as well.
 func WithBaseURL(base string) ClientOption {
 	return func(c *Client) error {
 		baseURL, err := url.Parse(base)
@@ -84,16 +120,46 @@ func WithBaseURL(base string) ClientOption {
 			return err
 		}
 		c.base = baseURL
+		c.customBase = true
 		return nil
 	}
 }
 
 type request struct {
+	// client is the API client the request is sent by.
+	client     *Client
 	httpClient *http.Client
 	req        *http.Request
+	// path is the escaped path of the request, relative to the server URL.
+	path string
+	// security are the security requirements of the request, see [WithSecurity].
+	security []SecurityRequirement
+	// idempotent is true if the request is sent with an idempotency key, see [WithIdempotency].
+	idempotent bool
+	// body is the request body sent as one of bodyMediaTypes, see [WithBody].
+	body           any
+	bodyMediaTypes []string
+	// contentType is the media type of the request body selected by [WithContentType].
+	contentType string
+	// response receives the metadata of the response, see [WithResponse].
+	response ResponseMetadata
+	// noCache is true if the request bypasses the cache of the client, see [WithoutCache].
+	noCache bool
+	// cacheKey is the key the response is cached under, the URL of the request before it
+	// is authorized, see [WithCache].
+	cacheKey string
+	// credentialHeaders are the names of the headers with API keys set when authorizing
+	// the request.
+	credentialHeaders []string
 }
 
 // Call executes a Petstore API call. Use [RequestOption]s to configure the request.
+//
+// The path is relative to the base URL of the client and must be escaped, that is
+// values interpolated into the path have to be escaped using [url.PathEscape]. Escape
+// sequences in the path are preserved as is (e.g. `%2F` is not treated as a path
+// separator) and a path with an invalid escape sequence, such as a literal `%`,
+// results in an error.
 func (c *Client) Call(
 	ctx context.Context, method, path string, opts ...RequestOption,
 ) (*http.Response, error) {
@@ -104,6 +170,8 @@ func (c *Client) Call(
 
 	r := &request{
 		req:        req,
+		path:       path,
+		client:     c,
 		httpClient: c.client,
 	}
 
@@ -113,53 +181,115 @@ func (c *Client) Call(
 		}
 	}
 
-	resp, err := r.httpClient.Do(r.req)
+	if err := r.encodeBody(); err != nil {
+		return nil, err
+	}
+
+	r.cacheKey = r.req.URL.String()
+	if err := c.authorize(r); err != nil {
+		return nil, err
+	}
+
+	r.setIdempotencyKey()
+
+	resp, err := c.send(r)
 	if err != nil {
 		return nil, err
 	}
 
+	if r.response != nil {
+		r.response.SetResponse(resp)
+	}
+
 	return resp, nil
 }
 
 // NewRequest returns a new [http.Request] given a method, URL, and
-// optional body.
+// optional body. The path is expected to be escaped (see [url.PathEscape])
+// and is appended to the base URL of the client, see [Client.Call] for details.
 //
 // NewRequest returns a Request suitable for use with
 // [Client.Do].
 func (c *Client) NewRequest(
 	ctx context.Context, method, path string, body io.Reader,
 ) (*http.Request, error) {
-	if !strings.HasPrefix(path, "/") {
-		path = "/" + path
+	u, err := resolveURL(c.base, path)
+	if err != nil {
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(
 		ctx,
 		method,
-		c.base.JoinPath(path).String(),
+		u.String(),
 		body,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("build request: %s", err.Error())
 	}
 
-	req.Header.Add("Authorization", "Bearer "+c.key)
-	req.Header.Add("SumUp-Version", version)
 	req.Header.Add("User-Agent", c.userAgent)
 
 	return req, nil
 }
 
+// resolveURL appends the escaped path to the base URL.
+func resolveURL(base *url.URL, path string) (*url.URL, error) {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %s", path, err.Error())
+	}
+
+	u := *base
+	u.Path = strings.TrimSuffix(base.Path, "/") + unescaped
+	u.RawPath = strings.TrimSuffix(base.EscapedPath(), "/") + path
+	return &u, nil
+}
+
+// defaultSecurity are the security requirements of the requests sent using [Client.Do].
+var defaultSecurity = []SecurityRequirement{}
+
 // Do sends an HTTP request and returns an HTTP response, following
 // policy (such as redirects, cookies, auth) as configured on the
-// HTTP client.
+// HTTP client. Same as requests made by [Client.Call], the request is
+// retried according to the retry policy of the client (see [WithRetryPolicy])
+// and its response is cached (see [WithCache]).
+//
+// The request is authorized using the credentials configured on the client for
+// the security requirements of the API. To send a request without credentials,
+// use [Client.Call] with [WithSecurity] given no requirements.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	resp, err := c.client.Do(req)
+	r := &request{
+		// credentials and the conditional headers of cached responses are set on a copy
+		req:        req.Clone(req.Context()),
+		client:     c,
+		httpClient: c.client,
+		security:   defaultSecurity,
+		cacheKey:   req.URL.String(),
+	}
+
+	if err := c.authorize(r); err != nil {
+		return nil, err
+	}
+
+	return c.send(r)
+}
+
+// send sends the request, serving the response from the cache of the client
+// if possible and retrying the request according to the retry policy.
+func (c *Client) send(r *request) (*http.Response, error) {
+	entry := c.cachedEntry(r)
+
+	resp, err := c.do(r)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp, nil
+	return c.cacheResponse(r, resp, entry)
 }
 
 // RequestOption is an option for the request made by the Petstore [Client].
@@ -183,6 +313,30 @@ func WithHeader(key, value string) RequestOption {
 	}
 }
 
+// WithHeaders returns a [RequestOption] that sets all the headers in h.
+// Any previously set values of the same headers will be overwritten.
+func WithHeaders(h http.Header) RequestOption {
+	return func(r *request) error {
+		for key, values := range h {
+			r.req.Header.Del(key)
+			for _, value := range values {
+				r.req.Header.Add(key, value)
+			}
+		}
+		return nil
+	}
+}
+
+// WithCookies returns a [RequestOption] that adds the cookies to the request.
+func WithCookies(cookies []*http.Cookie) RequestOption {
+	return func(r *request) error {
+		for _, cookie := range cookies {
+			r.req.AddCookie(cookie)
+		}
+		return nil
+	}
+}
+
 // WithBody returns a [RequestOption] that sets the request body as a JSON of the value v.
 func WithJSONBody(v any) RequestOption {
 	return func(r *request) error {
@@ -191,12 +345,70 @@ func WithJSONBody(v any) RequestOption {
 			return fmt.Errorf("encode json request body: %v", err)
 		}
 
-		r.req.Body = io.NopCloser(buf)
+		body := buf.Bytes()
+		r.req.Body = io.NopCloser(bytes.NewReader(body))
+		r.req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		r.req.ContentLength = int64(len(body))
 		r.req.Header.Set("Content-Type", "application/json")
 		return nil
 	}
 }
 
+// WithXMLBody returns a [RequestOption] that sets the request body as an XML of the value v.
+func WithXMLBody(v any) RequestOption {
+	return func(r *request) error {
+		buf := new(bytes.Buffer)
+		buf.WriteString(xml.Header)
+		if err := xml.NewEncoder(buf).Encode(v); err != nil {
+			return fmt.Errorf("encode xml request body: %v", err)
+		}
+
+		body := buf.Bytes()
+		r.req.Body = io.NopCloser(bytes.NewReader(body))
+		r.req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		r.req.ContentLength = int64(len(body))
+		r.req.Header.Set("Content-Type", "application/xml")
+		return nil
+	}
+}
+
+// WithFormBody returns a [RequestOption] that sets the request body as form-urlencoded values.
+func WithFormBody(values url.Values) RequestOption {
+	return func(r *request) error {
+		body := values.Encode()
+		r.req.Body = io.NopCloser(strings.NewReader(body))
+		r.req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(body)), nil
+		}
+		r.req.ContentLength = int64(len(body))
+		r.req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return nil
+	}
+}
+
+// WithURL returns a [RequestOption] that sends the request to the URL u, e.g. a link
+// to the next page of results returned by the API. Relative URLs are resolved against
+// the URL of the request. To not leak credentials, the URL must point to the same host.
+func WithURL(u string) RequestOption {
+	return func(r *request) error {
+		target, err := r.req.URL.Parse(u)
+		if err != nil {
+			return fmt.Errorf("parse url: %s", err.Error())
+		}
+
+		if target.Host != r.req.URL.Host {
+			return fmt.Errorf("url %q points to a different host than %q", u, r.req.URL.Host)
+		}
+
+		r.req.URL = target
+		return nil
+	}
+}
+
 // WithQueryValues returns a [RequestOption] that sets the request query params.
 func WithQueryValues(q url.Values) RequestOption {
 	return func(r *request) error {
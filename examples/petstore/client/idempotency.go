@@ -0,0 +1,58 @@
+// Code generated by `go-sdk-gen`. DO NOT EDIT.
+
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header the idempotency key of a request is sent in.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotency returns a [RequestOption] that sends the request with an idempotency key,
+// so that the API processes the request at most once. If the key is not set (e.g. using
+// [WithIdempotencyKey]), a random key is generated. The same key is sent on every retry
+// of the request, which makes the request safe to retry by [RetryPolicy] regardless of its method.
+func WithIdempotency() RequestOption {
+	return func(r *request) error {
+		r.idempotent = true
+		return nil
+	}
+}
+
+// WithIdempotencyKey returns a [RequestOption] that sends the request with the idempotency key.
+// Use it to retry a request that failed with the same key, see [APIError.IdempotencyKey].
+func WithIdempotencyKey(key string) RequestOption {
+	return func(r *request) error {
+		r.req.Header.Set(IdempotencyKeyHeader, key)
+		return nil
+	}
+}
+
+// NewIdempotencyKey returns a new random idempotency key (UUID version 4).
+func NewIdempotencyKey() string {
+	var uuid [16]byte
+	_, _ = rand.Read(uuid[:])
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // version 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:])
+}
+
+// IdempotencyKey returns the idempotency key the request of the response was sent with,
+// empty string if the request was sent without idempotency key.
+func IdempotencyKey(resp *http.Response) string {
+	if resp == nil || resp.Request == nil {
+		return ""
+	}
+	return resp.Request.Header.Get(IdempotencyKeyHeader)
+}
+
+// setIdempotencyKey generates the idempotency key of idempotent requests sent without one.
+func (r *request) setIdempotencyKey() {
+	if r.idempotent && r.req.Header.Get(IdempotencyKeyHeader) == "" {
+		r.req.Header.Set(IdempotencyKeyHeader, NewIdempotencyKey())
+	}
+}
@@ -0,0 +1,159 @@
+// Code generated by `go-sdk-gen`. DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// UnsupportedMediaTypeError is the error returned when a request body can't be sent
+// or a response body can't be decoded as the media type.
+type UnsupportedMediaTypeError struct {
+	// MediaType is the unsupported media type.
+	MediaType string
+	// Supported are the media types supported by the operation, empty if not known.
+	Supported []string
+}
+
+// Error implements the error interface.
+func (e *UnsupportedMediaTypeError) Error() string {
+	if len(e.Supported) == 0 {
+		return fmt.Sprintf("unsupported media type %q", e.MediaType)
+	}
+	return fmt.Sprintf("unsupported media type %q, expected one of %q", e.MediaType, e.Supported)
+}
+
+// FormBody is a request body encoded as application/x-www-form-urlencoded, see [WithFormBody].
+type FormBody interface {
+	// FormValues returns the form values of the body.
+	FormValues() url.Values
+}
+
+// WithBody returns a [RequestOption] that sets the request body for operations that accept
+// the body in multiple media types. The body is sent as the first of the media types unless
+// another one is selected using [WithContentType].
+func WithBody(body any, mediaTypes ...string) RequestOption {
+	return func(r *request) error {
+		r.body = body
+		r.bodyMediaTypes = mediaTypes
+		return nil
+	}
+}
+
+// WithContentType returns a [RequestOption] that selects the media type the request body
+// is sent as. The media type must be one of the media types accepted by the operation,
+// otherwise the request fails with [UnsupportedMediaTypeError].
+func WithContentType(mediaType string) RequestOption {
+	return func(r *request) error {
+		r.contentType = mediaType
+		return nil
+	}
+}
+
+// WithAccept returns a [RequestOption] that sets the Accept header to the media types,
+// in the order of preference.
+func WithAccept(mediaTypes ...string) RequestOption {
+	return func(r *request) error {
+		r.req.Header.Set("Accept", strings.Join(mediaTypes, ", "))
+		return nil
+	}
+}
+
+// encodeBody encodes the body set by [WithBody] as the selected media type.
+func (r *request) encodeBody() error {
+	if r.body == nil {
+		if r.contentType != "" {
+			r.req.Header.Set("Content-Type", r.contentType)
+		}
+		return nil
+	}
+
+	mediaType := r.contentType
+	if mediaType == "" && len(r.bodyMediaTypes) != 0 {
+		mediaType = r.bodyMediaTypes[0]
+	}
+
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil || !slices.ContainsFunc(r.bodyMediaTypes, func(mt string) bool {
+		supported, _, _ := mime.ParseMediaType(mt)
+		return supported == parsed
+	}) {
+		return &UnsupportedMediaTypeError{MediaType: mediaType, Supported: r.bodyMediaTypes}
+	}
+
+	switch body := r.body.(type) {
+	case FormBody:
+		if parsed == "application/x-www-form-urlencoded" {
+			return WithFormBody(body.FormValues())(r)
+		}
+	case MultipartBody:
+		if parsed == "multipart/form-data" {
+			return WithMultipartBody(body)(r)
+		}
+	}
+
+	var encode RequestOption
+	switch {
+	case isJSONMediaType(parsed):
+		encode = WithJSONBody(r.body)
+	case isXMLMediaType(parsed):
+		encode = WithXMLBody(r.body)
+	default:
+		return &UnsupportedMediaTypeError{MediaType: mediaType, Supported: r.bodyMediaTypes}
+	}
+
+	if err := encode(r); err != nil {
+		return err
+	}
+	r.req.Header.Set("Content-Type", mediaType)
+	return nil
+}
+
+// DecodeResponse decodes the body of the response into v according to the Content-Type
+// of the response, either as JSON or XML. The body is decoded as JSON if the response
+// has no Content-Type.
+// Returns [UnsupportedMediaTypeError] if the media type of the body is not supported.
+func DecodeResponse(resp *http.Response, v any) error {
+	return decode(resp.Header.Get("Content-Type"), resp.Body, v)
+}
+
+// decode decodes the body of the content type into v.
+func decode(contentType string, body io.Reader, v any) error {
+	if contentType == "" {
+		return json.NewDecoder(body).Decode(v)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return &UnsupportedMediaTypeError{MediaType: contentType}
+	}
+
+	switch {
+	case isJSONMediaType(mediaType):
+		return json.NewDecoder(body).Decode(v)
+	case isXMLMediaType(mediaType):
+		return xml.NewDecoder(body).Decode(v)
+	default:
+		return &UnsupportedMediaTypeError{MediaType: mediaType}
+	}
+}
+
+// isJSONMediaType reports whether the media type is `application/json` or any
+// structured syntax suffix `+json` type (e.g. `application/problem+json`).
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isXMLMediaType reports whether the media type is `application/xml`, `text/xml` or any
+// structured syntax suffix `+xml` type (e.g. `application/vnd.api+xml`).
+func isXMLMediaType(mediaType string) bool {
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
@@ -0,0 +1,96 @@
+// Code generated by `go-sdk-gen`. DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// problemMembers are the members of [Problem] defined by RFC 9457.
+var problemMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// Problem is the problem details of an HTTP API error as defined by RFC 9457
+// (`application/problem+json`). It is returned as [APIError.Err] for error responses
+// that use problem details without defining their schema.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type. When not present,
+	// its value is assumed to be "about:blank".
+	Type string `json:"type,omitempty"`
+	// Title is a short, human-readable summary of the problem type.
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code generated by the origin server for this
+	// occurrence of the problem.
+	Status int `json:"status,omitempty"`
+	// Detail is a human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference that identifies the specific occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// Extensions holds the extension members of the problem details, that is all members
+	// other than the ones defined above.
+	Extensions map[string]any `json:"-"`
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. Members not defined by RFC 9457 are
+// collected in [Problem.Extensions].
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	type problem Problem
+	var v problem
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var members map[string]any
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	for _, name := range problemMembers {
+		delete(members, name)
+	}
+	if len(members) != 0 {
+		v.Extensions = members
+	}
+
+	*p = Problem(v)
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler]. Extension members are encoded alongside
+// the members defined by RFC 9457.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	data, err := json.Marshal(problem(p))
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Extensions) == 0 {
+		return data, nil
+	}
+
+	members := make(map[string]any, len(p.Extensions)+len(problemMembers))
+	for name, value := range p.Extensions {
+		members[name] = value
+	}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(members)
+}
+
+// Error implements the error interface.
+func (p *Problem) Error() string {
+	switch {
+	case p.Title != "" && p.Detail != "":
+		return fmt.Sprintf("%s: %s", p.Title, p.Detail)
+	case p.Detail != "":
+		return p.Detail
+	case p.Title != "":
+		return p.Title
+	case p.Type != "":
+		return fmt.Sprintf("problem %s", p.Type)
+	default:
+		return "problem about:blank"
+	}
+}
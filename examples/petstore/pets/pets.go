@@ -4,7 +4,6 @@ package pets
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -57,12 +56,25 @@ func (p *ListPetsParams) QueryValues() url.Values {
 	q := make(url.Values)
 
 	if p.Limit != nil {
-		q.Set("limit", strconv.FormatInt(int64(*p.Limit), 10))
+		q.Add("limit", strconv.FormatInt(int64(*p.Limit), 10))
 	}
 
 	return q
 }
 
+// ListPetsResponseMeta is the metadata of the response to listPets, see [client.WithResponse].
+type ListPetsResponseMeta struct {
+	client.Response
+	// A link to the next page of responses
+	XNext *string
+}
+
+// SetResponse implements [client.ResponseMetadata].
+func (m *ListPetsResponseMeta) SetResponse(resp *http.Response) {
+	m.Response.SetResponse(resp)
+	m.XNext = client.HeaderValue[string](resp.Header, "x-next")
+}
+
 type PetsService struct {
 	c *client.Client
 }
@@ -72,40 +84,40 @@ func NewPetsService(c *client.Client) *PetsService {
 }
 
 // ListPets: List all pets
-func (s *PetsService) ListPets(ctx context.Context, params ListPetsParams) (*Pets, error) {
+//
+// The headers of the response are available through [ListPetsResponseMeta], see [client.WithResponse].
+func (s *PetsService) ListPets(ctx context.Context, params ListPetsParams, opts ...client.RequestOption) (*Pets, error) {
 	path := fmt.Sprintf("/pets")
 
-	resp, err := s.c.Call(ctx, http.MethodGet, path, client.WithQueryValues(params.QueryValues()))
+	opts = append([]client.RequestOption{client.WithAccept("application/json"), client.WithQueryValues(params.QueryValues())}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("error building request: %v", err)
+		return nil, fmt.Errorf("error building request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var v Pets
-		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
-			return nil, fmt.Errorf("decode response: %s", err.Error())
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
 		}
 
 		return &v, nil
 	default:
 		var apiErr Error
-		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
-			return nil, fmt.Errorf("read error response: %s", err.Error())
-		}
-
-		return nil, &apiErr
+		return nil, client.NewAPIError(resp, "unexpected error", &apiErr)
 	}
 }
 
 // CreatePets: Create a pet
-func (s *PetsService) CreatePets(ctx context.Context, body CreatePetsBody) error {
+func (s *PetsService) CreatePets(ctx context.Context, body CreatePetsBody, opts ...client.RequestOption) error {
 	path := fmt.Sprintf("/pets")
 
-	resp, err := s.c.Call(ctx, http.MethodPost, path, client.WithJSONBody(body))
+	opts = append([]client.RequestOption{client.WithJSONBody(body), client.WithAccept("application/json")}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodPost, path, opts...)
 	if err != nil {
-		return fmt.Errorf("error building request: %v", err)
+		return fmt.Errorf("error building request: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -114,38 +126,31 @@ func (s *PetsService) CreatePets(ctx context.Context, body CreatePetsBody) error
 		return nil
 	default:
 		var apiErr Error
-		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
-			return fmt.Errorf("read error response: %s", err.Error())
-		}
-
-		return &apiErr
+		return client.NewAPIError(resp, "unexpected error", &apiErr)
 	}
 }
 
 // ShowPetById: Info for a specific pet
-func (s *PetsService) ShowPetById(ctx context.Context, petId string) (*Pet, error) {
-	path := fmt.Sprintf("/pets/%v", petId)
+func (s *PetsService) ShowPetById(ctx context.Context, petId string, opts ...client.RequestOption) (*Pet, error) {
+	path := fmt.Sprintf("/pets/%s", url.PathEscape(petId))
 
-	resp, err := s.c.Call(ctx, http.MethodGet, path)
+	opts = append([]client.RequestOption{client.WithAccept("application/json")}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("error building request: %v", err)
+		return nil, fmt.Errorf("error building request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var v Pet
-		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
-			return nil, fmt.Errorf("decode response: %s", err.Error())
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
 		}
 
 		return &v, nil
 	default:
 		var apiErr Error
-		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
-			return nil, fmt.Errorf("read error response: %s", err.Error())
-		}
-
-		return nil, &apiErr
+		return nil, client.NewAPIError(resp, "unexpected error", &apiErr)
 	}
 }
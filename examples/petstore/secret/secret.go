@@ -0,0 +1,66 @@
+// Code generated by `go-sdk-gen`. DO NOT EDIT.
+
+// Package secret provides a type for sensitive values, such as passwords, that
+// must not leak into logs.
+package secret
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// redacted is printed instead of the secret value.
+const redacted = "[REDACTED]"
+
+// Secret holds a sensitive value. The value is redacted when formatted or logged
+// and is only revealed by [Secret.Value] and when encoded to be sent to the API.
+type Secret struct {
+	value string
+}
+
+// New returns a [Secret] holding the value.
+func New(value string) Secret {
+	return Secret{value: value}
+}
+
+// Value returns the secret value.
+func (s Secret) Value() string {
+	return s.value
+}
+
+// IsZero reports whether the secret is empty.
+func (s Secret) IsZero() bool {
+	return s.value == ""
+}
+
+// String implements [fmt.Stringer] and returns redacted value.
+func (s Secret) String() string {
+	return redacted
+}
+
+// GoString implements [fmt.GoStringer] and returns redacted value.
+func (s Secret) GoString() string {
+	return redacted
+}
+
+// Format implements [fmt.Formatter] so that the value is redacted for all verbs.
+func (s Secret) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(redacted))
+}
+
+// LogValue implements [slog.LogValuer] and returns redacted value.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// MarshalText implements [encoding.TextMarshaler]. The actual value is returned
+// as the secret needs to be sent to the API.
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.value), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (s *Secret) UnmarshalText(data []byte) error {
+	s.value = string(data)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
+	"net/url"
 	"path"
 	"slices"
 	"strings"
@@ -68,6 +69,9 @@ type Builder struct {
 	errorSchemas map[string]struct{}
 	pathsByTag   map[string]*openapi3.Paths
 
+	// xmlTags is true if the generated types are tagged for XML encoding, see [usesXML].
+	xmlTags bool
+
 	templates *template.Template
 
 	start time.Time
@@ -82,7 +86,59 @@ type Config struct {
 	// Module is the name of golang module.
 	Module string
 	// Name is the name of the product / service.
+	// Defaults to the title of the API.
 	Name string
+	// BaseURL is the default base URL of the generated client.
+	// Defaults to the URL of the first server of the API.
+	BaseURL string
+	// EnvVar is the name of the environment variable that the generated client reads
+	// the default credentials from. Defaults to `<PKGNAME>_API_KEY`.
+	EnvVar string
+	// VersionHeader is the name of the header that the generated client sends the
+	// version of the SDK in. The header is not sent if empty.
+	VersionHeader string
+	// UserAgent is the product name sent in the User-Agent header of the generated
+	// client, followed by the version of the SDK. Defaults to the package name.
+	UserAgent string
+}
+
+// withDefaults returns the config with the unset options populated from the specs.
+func (c Config) withDefaults(spec *openapi3.T) Config {
+	if c.Name == "" && spec.Info != nil {
+		c.Name = spec.Info.Title
+	}
+
+	if c.BaseURL == "" && len(spec.Servers) != 0 {
+		c.BaseURL = serverURL(spec.Servers[0])
+	}
+
+	if c.EnvVar == "" {
+		c.EnvVar = strings.ToUpper(strcase.ToSnake(c.PkgName)) + "_API_KEY"
+	}
+
+	if c.UserAgent == "" {
+		c.UserAgent = c.PkgName
+	}
+
+	return c
+}
+
+// validate checks that the config, populated with the defaults, can be used to generate
+// a working client.
+func (c Config) validate() error {
+	if c.BaseURL == "" {
+		return fmt.Errorf("missing base URL: the specs don't define any servers, set the base URL explicitly (`--base-url`)")
+	}
+
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL %q: %w", c.BaseURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid base URL %q: the URL must be absolute, set the base URL explicitly (`--base-url`)", c.BaseURL)
+	}
+
+	return nil
 }
 
 type Option func(b *Builder)
@@ -120,9 +176,18 @@ func New(cfg Config, opts ...Option) *Builder {
 func (b *Builder) Load(spec *openapi3.T) error {
 	b.start = time.Now()
 	b.spec = spec
+	b.cfg = b.cfg.withDefaults(spec)
+	if err := b.cfg.validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	b.xmlTags = usesXML(spec)
 
 	b.collectPaths()
 
+	if err := b.validateAllOf(); err != nil {
+		return fmt.Errorf("validate allOf schemas: %w", err)
+	}
+
 	b.collectSchemas()
 	if err := b.resolveSchemas(); err != nil {
 		return fmt.Errorf("resolve schemas: %w", err)
@@ -160,7 +225,11 @@ func (b *Builder) Build() error {
 		}
 	}
 
-	if err := b.writeClientPackage(path.Join(b.cfg.Out, "client/client.go")); err != nil {
+	if err := b.writeClientPackage(path.Join(b.cfg.Out, "client")); err != nil {
+		return err
+	}
+
+	if err := b.writeRuntimePackages(b.cfg.Out); err != nil {
 		return err
 	}
 
@@ -258,7 +327,9 @@ func (b *Builder) collectSchemas() {
 
 				if len(op.Tags) == 0 {
 					tag := "shared"
-					schemasByTag[tag] = append(schemasByTag[tag], schema.Ref)
+					if !slices.Contains(schemasByTag[tag], schema.Ref) {
+						schemasByTag[tag] = append(schemasByTag[tag], schema.Ref)
+					}
 					schemaRefs[schema.Ref] = []string{}
 				}
 
@@ -323,7 +394,9 @@ func (b *Builder) collectResponses() {
 
 				if len(op.Tags) == 0 {
 					tag := "shared"
-					responsesByTag[tag] = append(responsesByTag[tag], schema.Ref)
+					if !slices.Contains(responsesByTag[tag], schema.Ref) {
+						responsesByTag[tag] = append(responsesByTag[tag], schema.Ref)
+					}
 					tagsByResponse[schema.Ref] = []string{}
 				}
 
@@ -369,10 +442,14 @@ func (b *Builder) collectSchemasInResponse(op *openapi3.Operation) []*openapi3.S
 
 	schemas := make([]*openapi3.SchemaRef, 0, op.Responses.Len())
 	for code, response := range op.Responses.Map() {
-		// TODO: handle content-type correctly
-		for _, mediaType := range response.Value.Content {
+		for name, mediaType := range response.Value.Content {
+			// only JSON and XML bodies and items of streams are decoded into types,
+			// other media types are returned as is
 			schema := mediaType.Schema
-			if schema == nil {
+			if kind := streamKind(name); kind != "" {
+				schema = b.streamItemSchema(kind, mediaType)
+			}
+			if schema == nil || (!isDecodedMediaType(name) && streamKind(name) == "") {
 				continue
 			}
 			if code == "default" || !strings.HasPrefix(code, "2") {
@@ -0,0 +1,59 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestConfigWithDefaults(t *testing.T) {
+	spec := &openapi3.T{
+		Info: &openapi3.Info{Title: "Payments API"},
+		Servers: openapi3.Servers{
+			{
+				URL: "https://{region}.example.com/v1",
+				Variables: map[string]*openapi3.ServerVariable{
+					"region": {Default: "eu"},
+				},
+			},
+		},
+	}
+
+	got := Config{PkgName: "payments"}.withDefaults(spec)
+	want := Config{
+		PkgName:   "payments",
+		Name:      "Payments API",
+		BaseURL:   "https://eu.example.com/v1",
+		EnvVar:    "PAYMENTS_API_KEY",
+		UserAgent: "payments",
+	}
+	if got != want {
+		t.Fatalf("unexpected defaults:\n got: %+v\nwant: %+v", got, want)
+	}
+
+	overridden := Config{PkgName: "payments", Name: "Payments", BaseURL: "http://localhost", EnvVar: "KEY", UserAgent: "payments-go"}
+	if got := overridden.withDefaults(spec); got != overridden {
+		t.Fatalf("expected explicit config to be kept, got %+v", got)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		baseURL string
+		valid   bool
+	}{
+		"absolute": {baseURL: "https://api.example.com/v1", valid: true},
+		"empty":    {baseURL: ""},
+		"relative": {baseURL: "/v1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := Config{BaseURL: tc.baseURL}.validate()
+			if tc.valid && err != nil {
+				t.Fatalf("expected valid config, got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatalf("expected error for base URL %q", tc.baseURL)
+			}
+		})
+	}
+}
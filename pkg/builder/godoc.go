@@ -43,7 +43,14 @@ func operationGodoc(name string, operation *openapi3.Operation) string {
 // operationParamsGodoc creates godoc comment for a struct representing
 // parameters of an operation.
 func operationParamsGodoc(name string, operation *openapi3.Operation) string {
-	return formatGodoc(name + ": query parameters for " + operation.OperationID)
+	onlyQuery := !slices.ContainsFunc(operation.Parameters, func(p *openapi3.ParameterRef) bool {
+		return p.Value.In != "query" && p.Value.In != "path"
+	})
+	if onlyQuery {
+		return formatGodoc(name + ": query parameters for " + operation.OperationID)
+	}
+
+	return formatGodoc(name + ": parameters for " + operation.OperationID)
 }
 
 // schemaGodoc creates godoc for a schema.
@@ -0,0 +1,155 @@
+package builder
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/iancoleman/strcase"
+)
+
+// responseMetaName returns the name of the response metadata type of the operation, see
+// [Builder.responseMetaType].
+func responseMetaName(operationName string) string {
+	return operationName + "ResponseMeta"
+}
+
+// pathsToResponseMetaTypes generates response metadata types for operations that declare
+// response headers, see [Builder.responseMetaType].
+func (b *Builder) pathsToResponseMetaTypes(paths *openapi3.Paths) []Writable {
+	if paths == nil {
+		return nil
+	}
+
+	metaTypes := make([]Writable, 0)
+
+	for _, path := range paths.InMatchingOrder() {
+		pathSpec := paths.Find(path)
+		if pathSpec.Ref != "" {
+			slog.Warn(fmt.Sprintf("TODO: skipping path for %q, since it is a reference", path))
+			continue
+		}
+
+		operations := pathSpec.Operations()
+		operationKeys := slices.Collect(maps.Keys(operations))
+		slices.Sort(operationKeys)
+		for _, method := range operationKeys {
+			if typ := b.responseMetaType(operations[method]); typ != nil {
+				metaTypes = append(metaTypes, typ, &toResponseMeta{Typ: typ})
+			}
+		}
+	}
+
+	return metaTypes
+}
+
+// responseMetaType returns the response metadata type of the operation, that is a struct embedding
+// `client.Response` with a field for every header declared by the responses of the operation. Returns
+// nil if the operation declares no supported response headers.
+func (b *Builder) responseMetaType(o *openapi3.Operation) *TypeDeclaration {
+	fields := b.responseHeaderFields(o)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	name := responseMetaName(strcase.ToCamel(o.OperationID))
+	return &TypeDeclaration{
+		Type:      "struct",
+		Name:      name,
+		Comment:   formatGodoc(fmt.Sprintf("%s is the metadata of the response to %s, see [client.WithResponse].", name, o.OperationID)),
+		Fields:    append([]StructField{{Type: "client.Response", Embedded: true}}, fields...),
+		Operation: o,
+	}
+}
+
+// responseHeaderFields returns the fields of the response metadata type of the operation, a field
+// for every header declared by any of the responses of the operation. Headers are optional, scalar
+// headers are therefore pointers and array headers are slices. Headers of other types and the
+// `Content-Type` header, which is ignored by OpenAPI, are left out.
+func (b *Builder) responseHeaderFields(o *openapi3.Operation) []StructField {
+	if o.Responses == nil {
+		return nil
+	}
+
+	// header names are case-insensitive, the first declaration of a header wins
+	names := make(map[string]string)
+	headers := make(map[string]*openapi3.Header)
+	for _, code := range slices.Sorted(maps.Keys(o.Responses.Map())) {
+		response := o.Responses.Value(code)
+		if response == nil || response.Value == nil {
+			continue
+		}
+
+		for name, header := range response.Value.Headers {
+			if header == nil || header.Value == nil {
+				continue
+			}
+			key := http.CanonicalHeaderKey(name)
+			if _, ok := headers[key]; !ok && key != "Content-Type" {
+				names[key] = name
+				headers[key] = header.Value
+			}
+		}
+	}
+
+	fields := make([]StructField, 0, len(headers))
+	for _, key := range slices.Sorted(maps.Keys(headers)) {
+		name, header := names[key], headers[key]
+		schema := header.Schema
+		if schema == nil || schema.Value == nil {
+			slog.Warn("skipping response header without schema",
+				slog.String("operation", o.OperationID),
+				slog.String("header", name),
+			)
+			continue
+		}
+
+		isArray := isArraySchema(schema.Value) && schema.Value.Items != nil
+		if !isScalarSchema(schema.Value) && !(isArray && isScalarSchema(schema.Value.Items.Value)) {
+			slog.Warn("skipping response header of unsupported type",
+				slog.String("operation", o.OperationID),
+				slog.String("header", name),
+			)
+			continue
+		}
+
+		fields = append(fields, StructField{
+			Name:    name,
+			Type:    b.convertToValidGoType(strcase.ToCamel(o.OperationID)+strcase.ToCamel(name), schema),
+			Pointer: !isArray,
+			Comment: formatGodoc(strings.TrimSpace(header.Description)),
+			Schema:  schema,
+		})
+	}
+
+	return fields
+}
+
+// toResponseMeta generates method that sets the response metadata type from [http.Response],
+// parsing the declared headers into the typed fields.
+type toResponseMeta struct {
+	Typ *TypeDeclaration
+}
+
+func (e toResponseMeta) String() string {
+	buf := new(strings.Builder)
+	fmt.Fprint(buf, "// SetResponse implements [client.ResponseMetadata].\n")
+	fmt.Fprintf(buf, "func (m *%s) SetResponse(resp *http.Response) {\n", e.Typ.Name)
+	fmt.Fprint(buf, "\tm.Response.SetResponse(resp)\n")
+	for _, f := range e.Typ.Fields {
+		if f.Embedded {
+			continue
+		}
+		if f.Pointer {
+			fmt.Fprintf(buf, "\tm.%s = client.HeaderValue[%s](resp.Header, %q)\n", f.GoName(), f.Type, f.Name)
+		} else {
+			fmt.Fprintf(buf, "\tm.%s = client.HeaderValues[%s](resp.Header, %q)\n", f.GoName(), strings.TrimPrefix(f.Type, "[]"), f.Name)
+		}
+	}
+	fmt.Fprint(buf, "}\n")
+	return buf.String()
+}
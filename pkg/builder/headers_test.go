@@ -0,0 +1,49 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestResponseMetaType(t *testing.T) {
+	header := func(schema *openapi3.Schema) *openapi3.HeaderRef {
+		return &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{Schema: schema.NewRef()}}}
+	}
+	responses := openapi3.NewResponses(
+		openapi3.WithStatus(201, &openapi3.ResponseRef{Value: &openapi3.Response{Headers: openapi3.Headers{
+			"Location":              header(openapi3.NewStringSchema()),
+			"X-RateLimit-Remaining": header(openapi3.NewIntegerSchema()),
+			"X-Formats":             header(openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema())),
+			"X-Object":              header(openapi3.NewObjectSchema().WithProperty("id", openapi3.NewStringSchema())),
+			"Content-Type":          header(openapi3.NewStringSchema()),
+		}}}),
+		openapi3.WithStatus(429, &openapi3.ResponseRef{Value: &openapi3.Response{Headers: openapi3.Headers{
+			"x-ratelimit-remaining": header(openapi3.NewStringSchema()),
+			"Retry-After":           header(openapi3.NewIntegerSchema()),
+		}}}),
+	)
+	b := &Builder{spec: &openapi3.T{}}
+
+	typ := b.responseMetaType(&openapi3.Operation{OperationID: "createReport", Responses: responses})
+	if typ == nil || typ.Name != "CreateReportResponseMeta" {
+		t.Fatalf("unexpected type: %+v", typ)
+	}
+
+	want := `// SetResponse implements [client.ResponseMetadata].
+func (m *CreateReportResponseMeta) SetResponse(resp *http.Response) {
+	m.Response.SetResponse(resp)
+	m.Location = client.HeaderValue[string](resp.Header, "Location")
+	m.RetryAfter = client.HeaderValue[int](resp.Header, "Retry-After")
+	m.XFormats = client.HeaderValues[string](resp.Header, "X-Formats")
+	m.XRateLimitRemaining = client.HeaderValue[int](resp.Header, "X-RateLimit-Remaining")
+}
+`
+	if got := (toResponseMeta{Typ: typ}).String(); got != want {
+		t.Fatalf("unexpected SetResponse:\n got: %s\nwant: %s", got, want)
+	}
+
+	if typ := b.responseMetaType(&openapi3.Operation{OperationID: "getStatus", Responses: openapi3.NewResponses()}); typ != nil {
+		t.Fatalf("expected no type for operation without headers, got %+v", typ)
+	}
+}
@@ -0,0 +1,31 @@
+package builder
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// idempotencyKeyHeader is the header the idempotency key of a request is sent in.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// isIdempotencyKeyParam reports whether the parameter is the idempotency key header.
+func isIdempotencyKeyParam(p *openapi3.Parameter) bool {
+	return p.In == "header" && http.CanonicalHeaderKey(p.Name) == idempotencyKeyHeader
+}
+
+// isIdempotentOperation reports whether requests of the operation should be sent with an idempotency key,
+// that is if the operation accepts the idempotency key header or is marked with `x-idempotent: true`.
+func isIdempotentOperation(o *openapi3.Operation) bool {
+	if idempotent, ok := o.Extensions["x-idempotent"].(bool); ok {
+		return idempotent
+	}
+
+	for _, p := range o.Parameters {
+		if p.Value != nil && isIdempotencyKeyParam(p.Value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestIsIdempotentOperation(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation *openapi3.Operation
+		want      bool
+	}{
+		{
+			name: "idempotency key header",
+			operation: &openapi3.Operation{Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{Name: "idempotency-key", In: "header"}},
+			}},
+			want: true,
+		},
+		{
+			name: "idempotency key in query",
+			operation: &openapi3.Operation{Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{Name: "Idempotency-Key", In: "query"}},
+			}},
+			want: false,
+		},
+		{
+			name:      "extension",
+			operation: &openapi3.Operation{Extensions: map[string]any{"x-idempotent": true}},
+			want:      true,
+		},
+		{
+			name: "extension opt out",
+			operation: &openapi3.Operation{
+				Extensions: map[string]any{"x-idempotent": false},
+				Parameters: openapi3.Parameters{
+					{Value: &openapi3.Parameter{Name: "Idempotency-Key", In: "header"}},
+				},
+			},
+			want: false,
+		},
+		{
+			name:      "plain operation",
+			operation: &openapi3.Operation{},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isIdempotentOperation(tt.operation); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
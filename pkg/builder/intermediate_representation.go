@@ -26,9 +26,47 @@ type TypeDeclaration struct {
 	Schema    *openapi3.Schema
 }
 
+// OneOfDeclaration holds the information for generating a sum type from `oneOf` schema
+// or from multiple success responses of an operation.
 type OneOfDeclaration struct {
-	Name    string
-	Options []string
+	// Name of the type
+	Name string
+	// Comment holds the description of the type
+	Comment string
+	// Variants are the possible variants of the type, in the order they appear in the specs.
+	Variants []OneOfVariant
+	// Discriminator is the name of the property that determines the variant.
+	// Empty if the schema doesn't have a discriminator in which case the variants
+	// are tried in order.
+	Discriminator string
+
+	Schema *openapi3.Schema
+}
+
+// AnyOfDeclaration holds the information for generating a type from `anyOf` schema.
+// Unlike [OneOfDeclaration], multiple variants can be set at the same time.
+type AnyOfDeclaration struct {
+	// Name of the type
+	Name string
+	// Comment holds the description of the type
+	Comment string
+	// Variants are the possible variants of the type, in the order they appear in the specs.
+	Variants []OneOfVariant
+
+	Schema *openapi3.Schema
+}
+
+// OneOfVariant is a single variant of [OneOfDeclaration] or [AnyOfDeclaration].
+type OneOfVariant struct {
+	// Name of the variant, used as the name of the field and of the accessor.
+	Name string
+	// Type of the variant.
+	Type string
+	// DiscriminatorValues are the values of the discriminator property that map
+	// to this variant.
+	DiscriminatorValues []string
+	// Required are the required properties of the variant, if the variant is an object.
+	Required []string
 }
 
 // StructField holds the information for StructField of a type.
@@ -45,10 +83,22 @@ type StructField struct {
 	Optional bool
 	// Pointer indicates whether the field should be a pointer in the generated struct.
 	Pointer bool
+	// Embedded indicates whether the field is an embedded struct, in which case
+	// the name is ignored.
+	Embedded bool
+	// Nullable indicates whether the field is optional and can be explicitly set to null,
+	// in which case the type is wrapped in `nullable.Nullable`.
+	Nullable bool
 
 	Comment string
 
+	// Schema of the field, populated for fields created from schema properties.
+	Schema *openapi3.SchemaRef
+	// Parameter the field was created from, populated for fields of parameters structs.
 	Parameter *openapi3.Parameter
+	// Properties hold the fields of object parameters, used for serialization
+	// of the parameter.
+	Properties []StructField
 }
 
 type EnumOption[E cmp.Ordered] struct {
@@ -69,4 +119,11 @@ type Response struct {
 	Type           string
 	Code           int
 	ErrDescription string
+	// Binary is true if the response body is streamed to the caller as [binaryResponseType].
+	Binary bool
+	// Text is true if the response body is returned to the caller as string.
+	Text bool
+	// Stream is the kind of stream (see [streamKind]) if the response body is returned to the caller
+	// as a stream, empty otherwise.
+	Stream string
 }
@@ -0,0 +1,221 @@
+package builder
+
+import (
+	"log/slog"
+	"mime"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/iancoleman/strcase"
+)
+
+const (
+	mediaTypeJSON      = "application/json"
+	mediaTypeProblem   = "application/problem+json"
+	mediaTypeMultipart = "multipart/form-data"
+	mediaTypeForm      = "application/x-www-form-urlencoded"
+	mediaTypeText      = "text/plain"
+	mediaTypeXML       = "application/xml"
+)
+
+// binaryResponseType is the type of response bodies that are streamed to the caller.
+const binaryResponseType = "client.Binary"
+
+// isJSONMediaType reports whether the media type is JSON, that is `application/json`
+// or any structured syntax suffix `+json` type (e.g. `application/problem+json`).
+func isJSONMediaType(mediaType string) bool {
+	mt, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return mt == mediaTypeJSON || strings.HasSuffix(mt, "+json")
+}
+
+// jsonMediaType returns the JSON media type of the content along with its name, nil if the content
+// has no JSON media type. `application/json` is preferred over `application/problem+json`,
+// which is preferred over other `+json` types (in alphabetical order).
+func jsonMediaType(content openapi3.Content) (string, *openapi3.MediaType) {
+	if mt, ok := content[mediaTypeJSON]; ok {
+		return mediaTypeJSON, mt
+	}
+	if mt, ok := content[mediaTypeProblem]; ok {
+		return mediaTypeProblem, mt
+	}
+
+	names := make([]string, 0, len(content))
+	for name := range content {
+		if isJSONMediaType(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	slices.Sort(names)
+	return names[0], content[names[0]]
+}
+
+// isXMLMediaType reports whether the media type is XML, that is `application/xml`, `text/xml`
+// or any structured syntax suffix `+xml` type (e.g. `application/vnd.api+xml`).
+func isXMLMediaType(mediaType string) bool {
+	mt, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return mt == mediaTypeXML || mt == "text/xml" || strings.HasSuffix(mt, "+xml")
+}
+
+// isDecodedMediaType reports whether the generated client decodes bodies of the media type
+// into types, that is whether the media type is JSON or XML.
+func isDecodedMediaType(mediaType string) bool {
+	return isJSONMediaType(mediaType) || isXMLMediaType(mediaType)
+}
+
+// decodedMediaType returns the media type of the content that is decoded into types along with
+// its name, nil if the content has no such media type. JSON (see [jsonMediaType]) is preferred
+// over XML, `application/xml` is preferred over other XML types (in alphabetical order).
+func decodedMediaType(content openapi3.Content) (string, *openapi3.MediaType) {
+	if name, mt := jsonMediaType(content); mt != nil {
+		return name, mt
+	}
+	if mt, ok := content[mediaTypeXML]; ok {
+		return mediaTypeXML, mt
+	}
+
+	names := make([]string, 0, len(content))
+	for name := range content {
+		if isXMLMediaType(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	slices.Sort(names)
+	return names[0], content[names[0]]
+}
+
+// isProblemResponse reports whether the response is an RFC 9457 problem details response
+// without schema. Such responses are decoded into the `client.Problem` type.
+func isProblemResponse(response *openapi3.Response) bool {
+	name, mt := jsonMediaType(response.Content)
+	if mt == nil || mt.Schema != nil {
+		return false
+	}
+
+	parsed, _, err := mime.ParseMediaType(name)
+	return err == nil && parsed == mediaTypeProblem
+}
+
+// requestBodyMediaType returns the media type of the request body content that the generated
+// client sends by default along with its name, nil if the content has no supported media type.
+// See [requestBodyMediaTypes] for the order of preference.
+func requestBodyMediaType(content openapi3.Content) (string, *openapi3.MediaType) {
+	mediaTypes := requestBodyMediaTypes(content)
+	if len(mediaTypes) == 0 {
+		return "", nil
+	}
+	return mediaTypes[0], content[mediaTypes[0]]
+}
+
+// requestBodyMediaTypes returns the media types of the request body content that the generated
+// client can send, in the order of preference: JSON (see [jsonMediaType]) is preferred over XML,
+// which is preferred over form-urlencoded and multipart/form-data. Media types with schema different
+// from the schema of the preferred media type are left out, as the body is a single type.
+func requestBodyMediaTypes(content openapi3.Content) []string {
+	var mediaTypes []string
+	for _, is := range []func(string) bool{isJSONMediaType, isXMLMediaType} {
+		names := make([]string, 0, len(content))
+		for name := range content {
+			if is(name) {
+				names = append(names, name)
+			}
+		}
+		slices.SortFunc(names, compareMediaTypes)
+		mediaTypes = append(mediaTypes, names...)
+	}
+	mediaTypes = append(mediaTypes, mediaTypeForm, mediaTypeMultipart)
+
+	supported := make([]string, 0, len(mediaTypes))
+	for _, name := range mediaTypes {
+		mt, ok := content[name]
+		if !ok || mt.Schema == nil {
+			continue
+		}
+		if len(supported) != 0 && !sameSchema(content[supported[0]].Schema, mt.Schema) {
+			slog.Warn("skipping request body media type with different schema", slog.String("media_type", name))
+			continue
+		}
+		supported = append(supported, name)
+	}
+
+	return supported
+}
+
+// sameSchema reports whether the schemas are the same, either references to the same
+// schema or equal inline schemas.
+func sameSchema(a, b *openapi3.SchemaRef) bool {
+	if a.Ref != "" || b.Ref != "" {
+		return a.Ref == b.Ref
+	}
+	return reflect.DeepEqual(a.Value, b.Value)
+}
+
+// rawResponseType returns the type of response content that is neither JSON nor XML (see [rawBodyType]).
+// If the content has multiple media types, `string` is returned only if all of them are `text/plain`.
+// Returns empty string for JSON, XML or empty content.
+func rawResponseType(content openapi3.Content) string {
+	if len(content) == 0 {
+		return ""
+	}
+
+	if _, mt := decodedMediaType(content); mt != nil {
+		return ""
+	}
+
+	for name := range content {
+		if rawBodyType(name) != "string" {
+			return binaryResponseType
+		}
+	}
+
+	return "string"
+}
+
+// rawBodyType returns the type of response body of media type that is not JSON: `string`
+// for `text/plain` and [binaryResponseType] for other media types (e.g. `application/octet-stream`,
+// `application/pdf` or `text/csv`), which are streamed to the caller.
+func rawBodyType(mediaType string) string {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil && parsed == mediaTypeText {
+		return "string"
+	}
+	return binaryResponseType
+}
+
+// mediaTypeSuffix returns the suffix of the name of method variant that requests the media type,
+// e.g. `CSV` for `text/csv` or `Text` for `text/plain`.
+func mediaTypeSuffix(mediaType string) string {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		parsed = mediaType
+	}
+
+	switch parsed {
+	case mediaTypeText:
+		return "Text"
+	case "application/octet-stream":
+		return "Binary"
+	}
+
+	_, subtype, _ := strings.Cut(parsed, "/")
+	subtype = strings.TrimPrefix(subtype, "vnd.")
+	subtype, _, _ = strings.Cut(subtype, "+")
+	if len(subtype) <= 4 {
+		return strings.ToUpper(subtype)
+	}
+	return strcase.ToCamel(subtype)
+}
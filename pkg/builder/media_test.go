@@ -0,0 +1,148 @@
+package builder
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestJSONMediaType(t *testing.T) {
+	schema := &openapi3.SchemaRef{Ref: "#/components/schemas/Card"}
+
+	tests := []struct {
+		name    string
+		content openapi3.Content
+		want    string
+	}{
+		{
+			name: "json preferred",
+			content: openapi3.Content{
+				"application/problem+json": {},
+				"application/json":         {Schema: schema},
+			},
+			want: "application/json",
+		},
+		{
+			name: "problem preferred over vendor types",
+			content: openapi3.Content{
+				"application/vnd.api+json": {},
+				"application/problem+json": {},
+			},
+			want: "application/problem+json",
+		},
+		{
+			name: "vendor type",
+			content: openapi3.Content{
+				"text/plain":                        {},
+				"application/vnd.card+json; v=2":    {Schema: schema},
+				"application/vnd.merchant+json":     {Schema: schema},
+				"application/vnd.card+xml; v=2":     {},
+				"application/octet-stream":          {},
+				"application/x-www-form-urlencoded": {},
+			},
+			want: "application/vnd.card+json; v=2",
+		},
+		{
+			name:    "no json",
+			content: openapi3.Content{"text/plain": {}},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, mt := jsonMediaType(tt.content)
+			if got != tt.want {
+				t.Fatalf("got media type %q, want %q", got, tt.want)
+			}
+			if (mt == nil) != (tt.want == "") {
+				t.Fatalf("unexpected media type object %v", mt)
+			}
+		})
+	}
+}
+
+func TestIsProblemResponse(t *testing.T) {
+	problem := &openapi3.Response{Content: openapi3.Content{"application/problem+json": {}}}
+	if !isProblemResponse(problem) {
+		t.Fatal("expected problem+json without schema to be a problem response")
+	}
+
+	withSchema := &openapi3.Response{Content: openapi3.Content{
+		"application/problem+json": {Schema: &openapi3.SchemaRef{Ref: "#/components/schemas/Error"}},
+	}}
+	if isProblemResponse(withSchema) {
+		t.Fatal("expected problem+json with schema to use the schema")
+	}
+
+	if isProblemResponse(&openapi3.Response{}) {
+		t.Fatal("expected response without content not to be a problem response")
+	}
+}
+
+func TestRawResponseType(t *testing.T) {
+	tests := []struct {
+		name    string
+		content openapi3.Content
+		want    string
+	}{
+		{
+			name:    "empty",
+			content: openapi3.Content{},
+			want:    "",
+		},
+		{
+			name: "json",
+			content: openapi3.Content{
+				"application/json": {},
+				"text/plain":       {},
+			},
+			want: "",
+		},
+		{
+			name:    "text",
+			content: openapi3.Content{"text/plain; charset=utf-8": {}},
+			want:    "string",
+		},
+		{
+			name:    "pdf",
+			content: openapi3.Content{"application/pdf": {}},
+			want:    binaryResponseType,
+		},
+		{
+			name: "text and csv",
+			content: openapi3.Content{
+				"text/plain": {},
+				"text/csv":   {},
+			},
+			want: binaryResponseType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rawResponseType(tt.content); got != tt.want {
+				t.Errorf("rawResponseType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestBodyMediaTypes(t *testing.T) {
+	ref := &openapi3.SchemaRef{Ref: "#/components/schemas/Report", Value: openapi3.NewObjectSchema()}
+	other := &openapi3.SchemaRef{Ref: "#/components/schemas/Card", Value: openapi3.NewObjectSchema()}
+
+	content := openapi3.Content{
+		"multipart/form-data":               {Schema: other},
+		"application/x-www-form-urlencoded": {Schema: ref},
+		"application/vnd.report+json":       {Schema: ref},
+		"application/json":                  {Schema: ref},
+		"text/plain":                        {Schema: ref},
+	}
+
+	want := []string{"application/json", "application/vnd.report+json", "application/x-www-form-urlencoded"}
+	if got := requestBodyMediaTypes(content); !slices.Equal(got, want) {
+		t.Errorf("requestBodyMediaTypes() = %v, want %v", got, want)
+	}
+}
@@ -32,10 +32,34 @@ type Method struct {
 	HTTPMethod   string
 	FunctionName string
 	ResponseType *ResponseType
-	Path         string
-	PathParams   []Parameter
-	QueryParams  *Parameter
-	HasBody      bool
+	// Path holds the statements that build the `path` variable.
+	Path        string
+	PathParams  []Parameter
+	QueryParams *Parameter
+	HasQuery    bool
+	HasHeaders  bool
+	HasCookies  bool
+	HasBody     bool
+	// BodyMediaTypes are the media types the request body can be sent as, in the order
+	// of preference. Empty if the method has no body.
+	BodyMediaTypes []string
+	// Accept are the media types of the responses the method can decode, sent
+	// in the Accept header.
+	Accept []string
+	// Server is the server the method sends the request to, nil if the method
+	// uses the base URL of the client.
+	Server *Server
+	// Security is the Go code of the security requirements of the method,
+	// empty if the method doesn't require authorization.
+	Security string
+	// Idempotent is true if the request is sent with an idempotency key.
+	Idempotent bool
+	// Pagination describes how to iterate over pages of the response, nil if the
+	// operation is not paginated.
+	Pagination *Pagination
+	// ResponseMeta is the name of the response metadata type exposing the response
+	// headers declared by the operation, empty if the operation declares none.
+	ResponseMeta string
 	Responses    []Response
 }
 
@@ -50,9 +74,64 @@ func (mt Method) ParamsString() string {
 		res.WriteString(", ")
 		res.WriteString(fmt.Sprintf("%s %s", strcase.ToLowerCamel(mt.QueryParams.Name), mt.QueryParams.Type))
 	}
+	res.WriteString(", opts ...client.RequestOption")
 	return res.String()
 }
 
+// ArgsString returns the arguments the method is called with, without the request options.
+func (mt Method) ArgsString() string {
+	args := []string{"ctx"}
+	for _, p := range mt.PathParams {
+		args = append(args, strcase.ToLowerCamel(p.Name))
+	}
+	if mt.QueryParams != nil {
+		args = append(args, strcase.ToLowerCamel(mt.QueryParams.Name))
+	}
+	return strings.Join(args, ", ")
+}
+
+// RequestOptions returns the [client.RequestOption]s the method configures the request with,
+// joined by comma. The options passed by the caller are applied after these.
+func (mt Method) RequestOptions() string {
+	opts := make([]string, 0)
+	if mt.Server != nil {
+		opts = append(opts, fmt.Sprintf("client.WithOperationServer(%s)", serverString(mt.Server)))
+	}
+	switch {
+	case !mt.HasBody:
+	case len(mt.BodyMediaTypes) == 0 || slices.Equal(mt.BodyMediaTypes, []string{mediaTypeJSON}):
+		opts = append(opts, "client.WithJSONBody(body)")
+	case slices.Equal(mt.BodyMediaTypes, []string{mediaTypeXML}):
+		opts = append(opts, "client.WithXMLBody(body)")
+	case slices.Equal(mt.BodyMediaTypes, []string{mediaTypeMultipart}):
+		opts = append(opts, "client.WithMultipartBody(&body)")
+	case slices.Equal(mt.BodyMediaTypes, []string{mediaTypeForm}):
+		opts = append(opts, "client.WithFormBody(body.FormValues())")
+	default:
+		// the media type is selected by the caller, see `client.WithContentType`
+		opts = append(opts, fmt.Sprintf("client.WithBody(&body, %s)", quoteAll(mt.BodyMediaTypes)))
+	}
+	if len(mt.Accept) != 0 {
+		opts = append(opts, fmt.Sprintf("client.WithAccept(%s)", quoteAll(mt.Accept)))
+	}
+	if mt.HasQuery {
+		opts = append(opts, "client.WithQueryValues(params.QueryValues())")
+	}
+	if mt.HasHeaders {
+		opts = append(opts, "client.WithHeaders(params.Headers())")
+	}
+	if mt.HasCookies {
+		opts = append(opts, "client.WithCookies(params.Cookies())")
+	}
+	if mt.Idempotent {
+		opts = append(opts, "client.WithIdempotency()")
+	}
+	if mt.Security != "" {
+		opts = append(opts, fmt.Sprintf("client.WithSecurity(%s)", mt.Security))
+	}
+	return strings.Join(opts, ", ")
+}
+
 // pathsToMethods converts openapi3 path to golang methods.
 func (b *Builder) pathsToMethods(paths *openapi3.Paths) ([]*Method, error) {
 	allMethods := make([]*Method, 0, paths.Len())
@@ -89,35 +168,93 @@ func (b *Builder) pathToMethods(path string, p *openapi3.PathItem) ([]*Method, e
 		if err != nil {
 			return nil, err
 		}
+		method.Server = serverOverride(p, operationSpec)
 
 		methods = append(methods, method)
+		methods = append(methods, mediaTypeVariants(method, operationSpec)...)
 	}
 
 	return methods, nil
 }
 
-func pathBuilder(path string) string {
-	var res strings.Builder
+// pathBuilder generates statements that build the `path` variable from the path template
+// and path parameters. Values of the parameters are escaped using [url.PathEscape] and
+// serialized according to their `style` (`simple`, `label` or `matrix`).
+func pathBuilder(path string, params openapi3.Parameters) string {
+	var (
+		res     strings.Builder
+		format  strings.Builder
+		args    []string
+		literal = func(s string) { format.WriteString(strings.ReplaceAll(s, "%", "%%")) }
+	)
+
+	last := 0
+	for _, match := range pathParamRegexp.FindAllStringSubmatchIndex(path, -1) {
+		literal(path[last:match[0]])
+		last = match[1]
 
-	params := make([]string, 0)
-	for i, part := range strings.Split(path, "/") {
-		if i != 0 {
-			res.WriteString("/")
+		name := path[match[2]:match[3]]
+		idx := slices.IndexFunc(params, func(p *openapi3.ParameterRef) bool {
+			return p.Value != nil && p.Value.In == "path" && p.Value.Name == name
+		})
+		if idx == -1 {
+			slog.Warn("path parameter not defined", slog.String("path", path), slog.String("name", name))
+			format.WriteString("%v")
+			args = append(args, strcase.ToLowerCamel(name))
+			continue
 		}
-		match := pathParamRegexp.FindStringSubmatch(part)
-		if match == nil {
-			res.WriteString(part)
-		} else {
-			res.WriteString("%v")
-			params = append(params, strcase.ToLowerCamel(match[1]))
+
+		param := params[idx].Value
+		method, err := param.SerializationMethod()
+		if err != nil {
+			slog.Warn("unsupported parameter serialization",
+				slog.String("name", param.Name),
+				slog.String("error", err.Error()),
+			)
+			method = &openapi3.SerializationMethod{Style: openapi3.SerializationSimple}
 		}
+
+		prefix, separator := "", ","
+		switch method.Style {
+		case openapi3.SerializationLabel:
+			prefix = "."
+			if method.Explode {
+				separator = "."
+			}
+		case openapi3.SerializationMatrix:
+			prefix = ";" + param.Name + "="
+			if method.Explode {
+				separator = prefix
+			}
+		}
+		literal(prefix)
+		format.WriteString("%s")
+
+		variable := strcase.ToLowerCamel(param.Name)
+		schema := dereferenceSchema(param.Schema)
+		if schema != nil && schema.Value != nil && schema.Value.Type.Is("array") {
+			// every item is escaped separately so that the separators are kept
+			values := variable + "Values"
+			item := &openapi3.Parameter{Schema: schema.Value.Items}
+			fmt.Fprintf(&res, "%s := make([]string, 0, len(%s))\n", values, variable)
+			fmt.Fprintf(&res, "\tfor _, v := range %s {\n", variable)
+			fmt.Fprintf(&res, "\t\t%s = append(%s, url.PathEscape(%s))\n", values, values, paramToString("v", item))
+			fmt.Fprint(&res, "\t}\n\n\t")
+			args = append(args, fmt.Sprintf("strings.Join(%s, %q)", values, separator))
+			continue
+		}
+
+		args = append(args, fmt.Sprintf("url.PathEscape(%s)", paramToString(variable, param)))
 	}
+	literal(path[last:])
 
-	if len(params) == 0 {
-		return fmt.Sprintf("fmt.Sprintf(%q)", res.String())
+	if len(args) == 0 {
+		fmt.Fprintf(&res, "path := fmt.Sprintf(%q)", format.String())
+		return res.String()
 	}
 
-	return fmt.Sprintf("fmt.Sprintf(%q, %s)", res.String(), strings.Join(params, ", "))
+	fmt.Fprintf(&res, "path := fmt.Sprintf(%q, %s)", format.String(), strings.Join(args, ", "))
+	return res.String()
 }
 
 func (b *Builder) operationToMethod(method, path string, o *openapi3.Operation) (*Method, error) {
@@ -140,15 +277,14 @@ func (b *Builder) operationToMethod(method, path string, o *openapi3.Operation)
 		return nil, fmt.Errorf("build path parameters: %w", err)
 	}
 
-	hasBody := false
+	var bodyMediaTypes []string
 	if o.RequestBody != nil {
-		mt, ok := o.RequestBody.Value.Content["application/json"]
-		if ok && mt.Schema != nil {
+		bodyMediaTypes = requestBodyMediaTypes(o.RequestBody.Value.Content)
+		if len(bodyMediaTypes) != 0 {
 			params = append(params, Parameter{
 				Name: "body",
 				Type: strcase.ToCamel(o.OperationID) + "Body",
 			})
-			hasBody = true
 		}
 	}
 
@@ -166,6 +302,17 @@ func (b *Builder) operationToMethod(method, path string, o *openapi3.Operation)
 	for code, resp := range o.Responses.Map() {
 		operationName := strcase.ToCamel(o.OperationID)
 		typ := b.responseToType(operationName, resp, code)
+		if raw := rawResponseType(resp.Value.Content); typ == "" && strings.HasPrefix(code, "2") &&
+			respType != nil && respType.Type == raw {
+			typ = raw
+		}
+
+		var stream string
+		if name, mt := streamMediaType(resp.Value.Content); mt != nil && strings.HasPrefix(code, "2") &&
+			respType != nil && respType.Stream == streamKind(name) {
+			typ = respType.Type
+			stream = respType.Stream
+		}
 
 		description := code
 		if resp.Value.Description != nil {
@@ -179,6 +326,9 @@ func (b *Builder) operationToMethod(method, path string, o *openapi3.Operation)
 			Type:           typ,
 			Code:           statusCode,
 			ErrDescription: strings.TrimSpace(description),
+			Binary:         typ == binaryResponseType,
+			Text:           typ == "string",
+			Stream:         stream,
 		})
 	}
 
@@ -206,27 +356,61 @@ func (b *Builder) operationToMethod(method, path string, o *openapi3.Operation)
 		})
 	}
 
+	pagination, err := b.pagination(o, respType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pagination of operation %q: %w", o.OperationID, err)
+	}
+
+	var responseMeta string
+	if len(b.responseHeaderFields(o)) != 0 {
+		responseMeta = responseMetaName(strcase.ToCamel(o.OperationID))
+	}
+
 	slog.Info("generating method",
 		slog.String("id", o.OperationID),
 		slog.String("method_name", methodName),
 	)
 
 	return &Method{
-		Description:  operationGodoc(methodName, o),
-		HTTPMethod:   httpMethod(method),
-		FunctionName: methodName,
-		ResponseType: respType,
-		Path:         pathBuilder(path),
-		PathParams:   params,
-		QueryParams:  queryParams,
-		HasBody:      hasBody,
-		Responses:    responses,
+		Description:    operationGodoc(methodName, o),
+		HTTPMethod:     httpMethod(method),
+		FunctionName:   methodName,
+		ResponseType:   respType,
+		Path:           pathBuilder(path, o.Parameters),
+		PathParams:     params,
+		QueryParams:    queryParams,
+		HasQuery:       hasParamsIn(o, "query"),
+		HasHeaders:     hasParamsIn(o, "header"),
+		HasCookies:     hasParamsIn(o, "cookie"),
+		HasBody:        len(bodyMediaTypes) != 0,
+		BodyMediaTypes: bodyMediaTypes,
+		Accept:         acceptMediaTypes(o, respType.accepts),
+		Security:       securityRequirementsString(b.operationSecurity(o)),
+		Idempotent:     isIdempotentOperation(o),
+		Pagination:     pagination,
+		ResponseMeta:   responseMeta,
+		Responses:      responses,
 	}, nil
 }
 
+// hasParamsIn reports whether the operation has any parameters located in `in`.
+func hasParamsIn(o *openapi3.Operation, in string) bool {
+	return slices.ContainsFunc(o.Parameters, func(p *openapi3.ParameterRef) bool {
+		return p.Value.In == in
+	})
+}
+
 type ResponseType struct {
 	Type    string
 	IsOneOf bool
+	// Binary is true if the response body is streamed to the caller as [binaryResponseType].
+	Binary bool
+	// Text is true if the response body is returned to the caller as string.
+	Text bool
+	// Stream is the kind of stream (see [streamKind]) if the response body is returned to the caller
+	// as a stream of ItemType items, empty otherwise.
+	Stream   string
+	ItemType string
 }
 
 func (b *Builder) getSuccessResponseType(o *openapi3.Operation) (*ResponseType, error) {
@@ -257,7 +441,7 @@ func (b *Builder) getSuccessResponseType(o *openapi3.Operation) (*ResponseType,
 			response = b.spec.Components.Responses[ref]
 		}
 
-		if content, ok := response.Value.Content["application/json"]; ok {
+		if _, content := decodedMediaType(response.Value.Content); content != nil {
 			if content.Schema != nil {
 				successResponses = append(successResponses, responseInfo{
 					content: content,
@@ -268,7 +452,10 @@ func (b *Builder) getSuccessResponseType(o *openapi3.Operation) (*ResponseType,
 	}
 
 	if len(successResponses) == 0 {
-		return nil, nil
+		if streamType := b.streamSuccessResponseType(o); streamType != nil {
+			return streamType, nil
+		}
+		return b.rawSuccessResponseType(o), nil
 	}
 
 	if len(successResponses) == 1 {
@@ -292,13 +479,50 @@ func (b *Builder) getSuccessResponseType(o *openapi3.Operation) (*ResponseType,
 	}, nil
 }
 
+// rawSuccessResponseType returns type of the successful responses of the operation with content
+// that is not JSON (see [rawResponseType]), nil if there are no such responses or their types differ.
+func (b *Builder) rawSuccessResponseType(o *openapi3.Operation) *ResponseType {
+	var typ string
+	for code, response := range o.Responses.Map() {
+		if !strings.HasPrefix(code, "2") || response.Value == nil {
+			continue
+		}
+
+		raw := rawResponseType(response.Value.Content)
+		if raw == "" {
+			continue
+		}
+		if typ != "" && typ != raw {
+			return nil
+		}
+		typ = raw
+	}
+
+	if typ == "" {
+		return nil
+	}
+
+	return &ResponseType{
+		Type:   typ,
+		Binary: typ == binaryResponseType,
+		Text:   typ == "string",
+	}
+}
+
 func (b *Builder) responseToType(operationName string, resp *openapi3.ResponseRef, code string) string {
 	if resp.Ref != "" {
+		if isProblemResponse(resp.Value) {
+			return "client.Problem"
+		}
 		return strcase.ToCamel(strings.TrimPrefix(resp.Ref, "#/components/responses/")) + "Response"
 	}
 
-	content, ok := resp.Value.Content["application/json"]
-	if !ok {
+	if isProblemResponse(resp.Value) {
+		return "client.Problem"
+	}
+
+	_, content := decodedMediaType(resp.Value.Content)
+	if content == nil {
 		return ""
 	}
 
@@ -337,6 +561,11 @@ func (b *Builder) buildPathParams(paramType string, params openapi3.Parameters)
 			continue
 		}
 
+		if schema := p.Value.Schema; schema != nil && schema.Ref == "" && schema.Value != nil &&
+			schema.Value.AllOf != nil && singleAllOfMember(schema.Value) == nil {
+			return nil, fmt.Errorf("path parameter %q: allOf composition of multiple schemas is not supported", p.Value.Name)
+		}
+
 		pathParams = append(pathParams, Parameter{
 			Name: p.Value.Name,
 			Type: b.convertToValidGoType(p.Value.Name, p.Value.Schema),
@@ -365,34 +594,37 @@ func (b *Builder) convertToValidGoType(property string, r *openapi3.SchemaRef) s
 		}
 	}
 
-	// TODO: Handle AllOf
 	if r.Value.AllOf != nil {
-		if len(r.Value.AllOf) > 1 {
-			slog.Warn(fmt.Sprintf("TODO: allOf for %q has more than 1 item\n", property))
-			return "TODO"
+		if member := singleAllOfMember(r.Value); member != nil {
+			return b.convertToValidGoType(property, member)
 		}
 
-		return b.convertToValidGoType(property, r.Value.AllOf[0])
+		// Composition of multiple schemas, the type is generated by [Builder.createAllOf].
+		return strcase.ToCamel(property)
 	}
 
+	spec := withoutNullType(r.Value)
 	switch {
-	case r.Value.Type.Is("string"):
-		return formatStringType(r.Value)
-	case r.Value.Type.Is("integer"):
-		return formatIntegerType(r.Value)
-	case r.Value.Type.Is("number"):
-		return formatNumberType(r.Value)
-	case r.Value.Type.Is("boolean"):
+	case spec.Type.Is("string"):
+		return formatStringType(spec)
+	case spec.Type.Is("integer"):
+		return formatIntegerType(spec)
+	case spec.Type.Is("number"):
+		return formatNumberType(spec)
+	case spec.Type.Is("boolean"):
 		return "bool"
-	case r.Value.Type.Is("array"):
-		reference := b.getReferenceSchema(r.Value.Items)
+	case spec.Type.Is("array"):
+		reference := b.getReferenceSchema(spec.Items)
 		if reference != "" {
 			return fmt.Sprintf("[]%s", reference)
 		}
+		if spec.Items != nil && isPrimitiveSchema(spec.Items.Value) {
+			return "[]" + b.convertToValidGoType(property, spec.Items)
+		}
 		// TODO: handle if it is not a reference.
 		return "[]string"
-	case r.Value.Type.Is("object"):
-		if len(r.Value.Properties) == 0 {
+	case spec.Type.Is("object"):
+		if len(spec.Properties) == 0 {
 			// TODO: generate type alias?
 			slog.Warn("object with empty properties", slog.String("property", property))
 			return "interface{}"
@@ -402,7 +634,7 @@ func (b *Builder) convertToValidGoType(property string, r *openapi3.SchemaRef) s
 	default:
 		slog.Warn("unknown type, falling back to 'interface{}'",
 			slog.Any("property", property),
-			slog.Any("type", r.Value.Type),
+			slog.Any("type", spec.Type),
 		)
 		return "interface{}"
 	}
@@ -470,3 +702,13 @@ func formatNumberType(t *openapi3.Schema) string {
 		return "float64"
 	}
 }
+
+// isPrimitiveSchema reports whether the schema is of a primitive type that doesn't need
+// a type declaration of its own.
+func isPrimitiveSchema(schema *openapi3.Schema) bool {
+	if schema == nil || len(schema.Enum) != 0 {
+		return false
+	}
+	spec := withoutNullType(schema)
+	return slices.ContainsFunc([]string{"string", "integer", "number", "boolean"}, spec.Type.Is)
+}
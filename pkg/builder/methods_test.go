@@ -0,0 +1,84 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestPathBuilder(t *testing.T) {
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	array := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: str}}
+	explode := true
+	params := openapi3.Parameters{
+		{Value: &openapi3.Parameter{Name: "merchant_code", In: "path", Schema: str}},
+		{Value: &openapi3.Parameter{Name: "name", In: "path", Style: "label", Schema: str}},
+		{Value: &openapi3.Parameter{Name: "tags", In: "path", Style: "matrix", Explode: &explode, Schema: array}},
+	}
+
+	for path, want := range map[string]string{
+		"/merchants":                  `path := fmt.Sprintf("/merchants")`,
+		"/merchants/{merchant_code}":  `path := fmt.Sprintf("/merchants/%s", url.PathEscape(merchantCode))`,
+		"/files/{name}.json":          `path := fmt.Sprintf("/files/.%s.json", url.PathEscape(name))`,
+		"/100%/{merchant_code}/items": `path := fmt.Sprintf("/100%%/%s/items", url.PathEscape(merchantCode))`,
+		"/items{tags}": `tagsValues := make([]string, 0, len(tags))
+	for _, v := range tags {
+		tagsValues = append(tagsValues, url.PathEscape(v))
+	}
+
+	path := fmt.Sprintf("/items;tags=%s", strings.Join(tagsValues, ";tags="))`,
+	} {
+		if got := pathBuilder(path, params); got != want {
+			t.Errorf("pathBuilder(%q):\n got: %s\nwant: %s", path, got, want)
+		}
+	}
+}
+
+func TestMethodRequestOptions(t *testing.T) {
+	method := Method{
+		HasBody:    true,
+		HasQuery:   true,
+		Server:     &Server{URL: "https://files.example.com"},
+		Security:   `client.SecurityRequirement{"apiKey"}`,
+		Idempotent: true,
+	}
+
+	want := `client.WithOperationServer(client.Server{URL: "https://files.example.com"}), client.WithJSONBody(body), ` +
+		`client.WithQueryValues(params.QueryValues()), client.WithIdempotency(), ` +
+		`client.WithSecurity(client.SecurityRequirement{"apiKey"})`
+	if got := method.RequestOptions(); got != want {
+		t.Fatalf("unexpected request options:\n got: %s\nwant: %s", got, want)
+	}
+
+	method = Method{
+		HasBody:        true,
+		BodyMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Accept:         []string{"application/json"},
+	}
+
+	want = `client.WithBody(&body, "application/json", "application/x-www-form-urlencoded"), ` +
+		`client.WithAccept("application/json")`
+	if got := method.RequestOptions(); got != want {
+		t.Fatalf("unexpected request options:\n got: %s\nwant: %s", got, want)
+	}
+
+	if got := (Method{}).RequestOptions(); got != "" {
+		t.Fatalf("expected no request options, got %s", got)
+	}
+}
+
+func TestBuildPathParamsRejectsAllOf(t *testing.T) {
+	object := &openapi3.Types{"object"}
+	composed := &openapi3.SchemaRef{Value: &openapi3.Schema{AllOf: openapi3.SchemaRefs{
+		{Value: &openapi3.Schema{Type: object, Properties: openapi3.Schemas{"id": {Value: openapi3.NewStringSchema()}}}},
+		{Value: &openapi3.Schema{Type: object, Properties: openapi3.Schemas{"name": {Value: openapi3.NewStringSchema()}}}},
+	}}}
+
+	b := New(Config{})
+	_, err := b.buildPathParams("path", openapi3.Parameters{
+		{Value: &openapi3.Parameter{Name: "card", In: "path", Schema: composed}},
+	})
+	if err == nil {
+		t.Fatal("expected error for path parameter composed of multiple schemas")
+	}
+}
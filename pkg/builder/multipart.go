@@ -0,0 +1,119 @@
+package builder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// multipartFileType is the type of `format: binary` properties of multipart/form-data bodies.
+const multipartFileType = "client.File"
+
+// toMultipart generates method that writes multipart/form-data request body.
+type toMultipart struct {
+	Typ *TypeDeclaration
+	// Encoding of the parts by the property name, see https://spec.openapis.org/oas/v3.1.0#encoding-object.
+	Encoding map[string]*openapi3.Encoding
+}
+
+func (e toMultipart) String() string {
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "// WriteMultipart writes [%s] as parts of multipart/form-data body.\n", e.Typ.Name)
+	fmt.Fprintf(buf, "func (b *%s) WriteMultipart(w *multipart.Writer) error {\n", e.Typ.Name)
+	for _, f := range e.Typ.Fields {
+		var contentType string
+		if encoding, ok := e.Encoding[f.Name]; ok && encoding != nil {
+			contentType = encoding.ContentType
+		}
+
+		writeMultipartPart(buf, f, contentType)
+		fmt.Fprint(buf, "\n")
+	}
+	fmt.Fprint(buf, "\treturn nil\n")
+	fmt.Fprint(buf, "}\n")
+	return buf.String()
+}
+
+// writeMultipartPart writes statements that write the field as a part (or parts, for arrays)
+// of multipart/form-data body. Files are written as file parts, primitive values as form fields
+// and other values as JSON. The content type overrides the default content type of the part.
+func writeMultipartPart(buf *strings.Builder, f StructField, contentType string) {
+	name := f.Name
+	access := "b." + f.GoName()
+	schema := dereferenceSchema(f.Schema)
+	if schema == nil || schema.Value == nil {
+		return
+	}
+
+	write := func(value string, item *openapi3.SchemaRef) string {
+		var call string
+		switch {
+		case isBinarySchema(item.Value):
+			call = fmt.Sprintf("client.WriteMultipartFile(w, %q, %s, %q)", name, value, contentType)
+		case isScalarSchema(item.Value) && (contentType == "" || strings.HasPrefix(contentType, "text/plain")):
+			call = fmt.Sprintf("w.WriteField(%q, %s)", name, paramToString(value, &openapi3.Parameter{Schema: item}))
+		case isScalarSchema(item.Value):
+			call = fmt.Sprintf("client.WriteMultipartValue(w, %q, %s, %q)", name, paramToString(value, &openapi3.Parameter{Schema: item}), contentType)
+		default:
+			call = fmt.Sprintf("client.WriteMultipartJSON(w, %q, %s, %q)", name, value, contentType)
+		}
+		return fmt.Sprintf("if err := %s; err != nil {\n\treturn fmt.Errorf(\"write %s: %%w\", err)\n}", call, name)
+	}
+
+	switch {
+	case f.Nullable:
+		fmt.Fprintf(buf, "\tif v, ok := %s.Get(); ok {\n", access)
+		fmt.Fprintf(buf, "\t\t%s\n", write("v", schema))
+		fmt.Fprint(buf, "\t}\n")
+	case schema.Value.Type.Is("array") && schema.Value.Items != nil:
+		// arrays are written as a part per item
+		fmt.Fprintf(buf, "\tfor _, v := range %s {\n", access)
+		fmt.Fprintf(buf, "\t\t%s\n", write("v", dereferenceSchema(schema.Value.Items)))
+		fmt.Fprint(buf, "\t}\n")
+	case f.Pointer:
+		fmt.Fprintf(buf, "\tif %s != nil {\n", access)
+		fmt.Fprintf(buf, "\t\t%s\n", write("*"+access, schema))
+		fmt.Fprint(buf, "\t}\n")
+	default:
+		fmt.Fprintf(buf, "\t%s\n", write(access, schema))
+	}
+}
+
+// multipartFields returns the fields of multipart/form-data body with binary properties
+// converted to files. The JSON tags are removed as the body is not encoded as JSON.
+func multipartFields(fields []StructField) []StructField {
+	converted := make([]StructField, 0, len(fields))
+	for _, f := range fields {
+		f.Tags = nil
+		if schema := dereferenceSchema(f.Schema); schema != nil && schema.Value != nil {
+			switch {
+			case isBinarySchema(schema.Value):
+				// the format is implied by the type
+				withoutFormat := *schema.Value
+				withoutFormat.Format = ""
+				f.Type = multipartFileType
+				f.Comment = schemaPropertyGodoc(&withoutFormat)
+			case isArraySchema(schema.Value) && schema.Value.Items != nil && isBinarySchema(schema.Value.Items.Value):
+				f.Type = "[]" + multipartFileType
+			}
+		}
+		converted = append(converted, f)
+	}
+	return converted
+}
+
+// isScalarSchema reports whether the schema is a string, integer, number or boolean,
+// including enums.
+func isScalarSchema(schema *openapi3.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	return slices.ContainsFunc([]string{"string", "integer", "number", "boolean"}, withoutNullType(schema).Type.Is)
+}
+
+// isBinarySchema reports whether the schema describes binary content, e.g. a file.
+func isBinarySchema(schema *openapi3.Schema) bool {
+	return schema != nil && schema.Type.Is("string") && schema.Format == "binary"
+}
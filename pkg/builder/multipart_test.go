@@ -0,0 +1,82 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestMultipartFields(t *testing.T) {
+	binary := &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "binary"}
+	fields := multipartFields([]StructField{
+		{Name: "file", Type: "string", Schema: binary.NewRef(), Tags: map[string][]string{"json": {"file"}}},
+		{Name: "files", Type: "[]string", Schema: openapi3.NewArraySchema().WithItems(binary).NewRef()},
+		{Name: "name", Type: "string", Schema: openapi3.NewStringSchema().NewRef()},
+	})
+
+	want := []string{"client.File", "[]client.File", "string"}
+	for i, f := range fields {
+		if f.Type != want[i] {
+			t.Errorf("field %q: got type %q, want %q", f.Name, f.Type, want[i])
+		}
+		if f.Tags != nil {
+			t.Errorf("field %q: expected no tags, got %v", f.Name, f.Tags)
+		}
+	}
+}
+
+func TestWriteMultipartPart(t *testing.T) {
+	tests := []struct {
+		name        string
+		field       StructField
+		contentType string
+		want        string
+	}{
+		{
+			name: "file",
+			field: StructField{
+				Name:   "file",
+				Schema: (&openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "binary"}).NewRef(),
+			},
+			contentType: "image/png",
+			want:        `client.WriteMultipartFile(w, "file", b.File, "image/png")`,
+		},
+		{
+			name: "optional integer",
+			field: StructField{
+				Name:    "amount",
+				Schema:  openapi3.NewIntegerSchema().NewRef(),
+				Pointer: true,
+			},
+			want: `w.WriteField("amount", strconv.Itoa(*b.Amount))`,
+		},
+		{
+			name: "text with content type",
+			field: StructField{
+				Name:   "notes",
+				Schema: openapi3.NewStringSchema().NewRef(),
+			},
+			contentType: "text/markdown",
+			want:        `client.WriteMultipartValue(w, "notes", b.Notes, "text/markdown")`,
+		},
+		{
+			name: "object",
+			field: StructField{
+				Name:   "metadata",
+				Schema: openapi3.NewObjectSchema().WithProperty("key", openapi3.NewStringSchema()).NewRef(),
+			},
+			want: `client.WriteMultipartJSON(w, "metadata", b.Metadata, "")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(strings.Builder)
+			writeMultipartPart(buf, tt.field, tt.contentType)
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Fatalf("expected %s in:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package builder
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// accepts reports whether the media type of successful response is decoded into the response type.
+func (rt *ResponseType) accepts(mediaType string) bool {
+	switch {
+	case rt == nil:
+		return false
+	case rt.Stream != "":
+		return streamKind(mediaType) == rt.Stream
+	case rt.Binary:
+		return !isDecodedMediaType(mediaType)
+	case rt.Text:
+		return rawBodyType(mediaType) == "string"
+	default:
+		return isDecodedMediaType(mediaType)
+	}
+}
+
+// acceptMediaTypes returns the media types that the client accepts in responses to the operation:
+// the media types of the successful responses that are accepted by `accepts`, followed by the JSON
+// and XML media types of the error responses (see [compareMediaTypes] for the order of preference).
+func acceptMediaTypes(o *openapi3.Operation, accepts func(mediaType string) bool) []string {
+	if o.Responses == nil {
+		return nil
+	}
+
+	var success, errs []string
+	responses := o.Responses.Map()
+	for _, code := range slices.Sorted(maps.Keys(responses)) {
+		resp := responses[code]
+		if resp.Value == nil {
+			continue
+		}
+
+		for _, name := range slices.SortedFunc(maps.Keys(resp.Value.Content), compareMediaTypes) {
+			switch {
+			case strings.HasPrefix(code, "2"):
+				if accepts(name) && !slices.Contains(success, name) {
+					success = append(success, name)
+				}
+			case isDecodedMediaType(name) && !slices.Contains(errs, name):
+				errs = append(errs, name)
+			}
+		}
+	}
+
+	mediaTypes := success
+	for _, name := range errs {
+		if !slices.Contains(mediaTypes, name) {
+			mediaTypes = append(mediaTypes, name)
+		}
+	}
+	return mediaTypes
+}
+
+// compareMediaTypes orders `application/json` and `application/xml` before other media types,
+// which are ordered alphabetically.
+func compareMediaTypes(a, b string) int {
+	rank := func(mediaType string) int {
+		switch mediaType {
+		case mediaTypeJSON:
+			return 0
+		case mediaTypeXML:
+			return 1
+		default:
+			return 2
+		}
+	}
+	return cmp.Or(cmp.Compare(rank(a), rank(b)), cmp.Compare(a, b))
+}
+
+// mediaTypeVariants returns variants of the method that request the media types of the successful
+// responses that are neither JSON, XML nor streamed, if the method itself decodes the body. The
+// variants are named after the media type, e.g. `ExportCSV` for `text/csv` (see [mediaTypeSuffix]),
+// and return the body as [binaryResponseType] or string. Successful responses that don't offer
+// the media type are unexpected for the variant.
+func mediaTypeVariants(method *Method, o *openapi3.Operation) []*Method {
+	rt := method.ResponseType
+	if rt == nil || rt.Binary || rt.Text || rt.Stream != "" {
+		return nil
+	}
+
+	var mediaTypes []string
+	for code, resp := range o.Responses.Map() {
+		if !strings.HasPrefix(code, "2") || resp.Value == nil {
+			continue
+		}
+		for name := range resp.Value.Content {
+			if !isDecodedMediaType(name) && streamKind(name) == "" && !slices.Contains(mediaTypes, name) {
+				mediaTypes = append(mediaTypes, name)
+			}
+		}
+	}
+	slices.Sort(mediaTypes)
+
+	variants := make([]*Method, 0, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		typ := rawBodyType(mediaType)
+		respType := &ResponseType{
+			Type:   typ,
+			Binary: typ == binaryResponseType,
+			Text:   typ == "string",
+		}
+
+		variant := *method
+		variant.FunctionName = method.FunctionName + mediaTypeSuffix(mediaType)
+		doc := variant.FunctionName
+		if o.Summary != "" {
+			doc += ": " + strings.TrimSuffix(o.Summary, ".")
+		}
+		variant.Description = formatGodoc(fmt.Sprintf("%s, requesting the response as %s.", doc, mediaType))
+		variant.ResponseType = respType
+		variant.Accept = acceptMediaTypes(o, func(name string) bool { return name == mediaType })
+		variant.Pagination = nil
+		variant.Responses = make([]Response, 0, len(method.Responses))
+		for _, resp := range method.Responses {
+			if !resp.IsErr && resp.Type != "" {
+				if spec := o.Responses.Value(strconv.Itoa(resp.Code)); spec != nil && spec.Value != nil && spec.Value.Content[mediaType] != nil {
+					resp.Type = typ
+					resp.Binary = respType.Binary
+					resp.Text = respType.Text
+				} else {
+					resp.Type = ""
+					resp.IsUnexpected = true
+				}
+			}
+			variant.Responses = append(variant.Responses, resp)
+		}
+
+		variants = append(variants, &variant)
+	}
+
+	return variants
+}
+
+// quoteAll returns the strings quoted as Go string literals, joined by comma.
+func quoteAll(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return strings.Join(quoted, ", ")
+}
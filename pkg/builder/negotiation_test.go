@@ -0,0 +1,77 @@
+package builder
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestMediaTypeVariants(t *testing.T) {
+	content := openapi3.NewContentWithJSONSchema(openapi3.NewObjectSchema())
+	content["text/csv"] = openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema())
+	content["text/plain"] = openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema())
+
+	responses := openapi3.NewResponses(
+		openapi3.WithStatus(200, &openapi3.ResponseRef{Value: &openapi3.Response{Content: content}}),
+		openapi3.WithStatus(400, &openapi3.ResponseRef{Value: &openapi3.Response{
+			Content: openapi3.Content{"application/problem+json": openapi3.NewMediaType()},
+		}}),
+	)
+	responses.Delete("default")
+	o := &openapi3.Operation{OperationID: "exportReport", Summary: "Export report.", Responses: responses}
+
+	method := &Method{
+		FunctionName: "ExportReport",
+		ResponseType: &ResponseType{Type: "ExportReport200Response"},
+		Accept:       acceptMediaTypes(o, (&ResponseType{}).accepts),
+		Responses: []Response{
+			{Code: 200, Type: "ExportReport200Response"},
+			{Code: 400, IsErr: true, Type: "client.Problem"},
+		},
+	}
+	if want := []string{"application/json", "application/problem+json"}; !slices.Equal(method.Accept, want) {
+		t.Fatalf("unexpected accept: got %v, want %v", method.Accept, want)
+	}
+
+	variants := mediaTypeVariants(method, o)
+	if len(variants) != 2 {
+		t.Fatalf("expected 2 variants, got %d", len(variants))
+	}
+
+	csv, text := variants[0], variants[1]
+	if csv.FunctionName != "ExportReportCSV" || !csv.ResponseType.Binary || !csv.Responses[0].Binary {
+		t.Errorf("unexpected csv variant: %+v", csv)
+	}
+	if want := []string{"text/csv", "application/problem+json"}; !slices.Equal(csv.Accept, want) {
+		t.Errorf("unexpected csv accept: got %v, want %v", csv.Accept, want)
+	}
+	if csv.Description != "ExportReportCSV: Export report, requesting the response as text/csv." {
+		t.Errorf("unexpected csv description: %s", csv.Description)
+	}
+	if text.FunctionName != "ExportReportText" || text.ResponseType.Type != "string" || !text.Responses[0].Text {
+		t.Errorf("unexpected text variant: %+v", text)
+	}
+	if text.Responses[1].Type != "client.Problem" {
+		t.Errorf("expected error responses to be kept, got %+v", text.Responses[1])
+	}
+
+	if variants := mediaTypeVariants(csv, o); len(variants) != 0 {
+		t.Errorf("expected no variants of raw method, got %d", len(variants))
+	}
+}
+
+func TestMediaTypeSuffix(t *testing.T) {
+	for mediaType, want := range map[string]string{
+		"text/csv":                  "CSV",
+		"text/plain; charset=utf-8": "Text",
+		"application/octet-stream":  "Binary",
+		"application/pdf":           "PDF",
+		"image/svg+xml":             "SVG",
+		"application/vnd.ms-excel":  "MsExcel",
+	} {
+		if got := mediaTypeSuffix(mediaType); got != want {
+			t.Errorf("mediaTypeSuffix(%q) = %q, want %q", mediaType, got, want)
+		}
+	}
+}
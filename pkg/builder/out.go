@@ -43,6 +43,9 @@ func (b *Builder) generateResource(tagName string, paths *openapi3.Paths) error
 	responseTypes := b.pathsToResponseTypes(paths)
 	types = append(types, responseTypes...)
 
+	metaTypes := b.pathsToResponseMetaTypes(paths)
+	types = append(types, metaTypes...)
+
 	respTypes := b.respToTypes(resolvedResponses, b.errorSchemas)
 	types = append(types, respTypes...)
 
@@ -121,10 +124,13 @@ func (b *Builder) writeClientFile(fname string, tags []string) error {
 	})
 
 	if err := b.templates.ExecuteTemplate(f, "base.go.tmpl", map[string]any{
-		"PackageName": b.cfg.PkgName,
-		"Module":      b.cfg.Module,
-		"Version":     b.spec.Info.Version,
-		"Resources":   resources,
+		"Name":                  b.cfg.Name,
+		"PackageName":           b.cfg.PkgName,
+		"Module":                b.cfg.Module,
+		"Version":               b.spec.Info.Version,
+		"EnvVar":                b.cfg.EnvVar,
+		"Resources":             resources,
+		"HasDefaultCredentials": defaultSecurityScheme(b.securitySchemes(), b.spec.Security) != nil,
 	}); err != nil {
 		return fmt.Errorf("generate client: %w", err)
 	}
@@ -132,24 +138,89 @@ func (b *Builder) writeClientFile(fname string, tags []string) error {
 	return nil
 }
 
-func (b *Builder) writeClientPackage(fname string) error {
-	if err := os.MkdirAll(path.Dir(fname), os.ModePerm); err != nil {
+// writeClientPackage writes the client package to the directory dir.
+func (b *Builder) writeClientPackage(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
 		return err
 	}
 
-	f, err := os.OpenFile(fname, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0o755))
-	if err != nil {
-		return fmt.Errorf("create %q: %w", fname, err)
+	schemes := b.securitySchemes()
+	defaultScheme := defaultSecurityScheme(schemes, b.spec.Security)
+	for _, file := range []string{"client.go", "auth.go", "binary.go", "cache.go", "errors.go", "idempotency.go", "media.go", "multipart.go", "problem.go", "retry.go", "response.go", "server.go", "stream.go"} {
+		fname := path.Join(dir, file)
+		f, err := os.OpenFile(fname, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0o755))
+		if err != nil {
+			return fmt.Errorf("create %q: %w", fname, err)
+		}
+
+		if err := b.templates.ExecuteTemplate(f, file+".tmpl", map[string]any{
+			"Name":                  b.cfg.Name,
+			"PackageName":           b.cfg.PkgName,
+			"Module":                b.cfg.Module,
+			"Version":               b.spec.Info.Version,
+			"BaseURL":               b.cfg.BaseURL,
+			"EnvVar":                b.cfg.EnvVar,
+			"VersionHeader":         b.cfg.VersionHeader,
+			"UserAgent":             b.cfg.UserAgent,
+			"Servers":               b.servers(),
+			"SecuritySchemes":       schemes,
+			"DefaultSecurityScheme": defaultScheme,
+			"Security":              clientSecurity(b.spec.Security, defaultScheme),
+		}); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("generate client: %w", err)
+		}
+
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("close file %q: %w", fname, err)
+		}
 	}
-	defer func() { _ = f.Close() }()
 
-	if err := b.templates.ExecuteTemplate(f, "client.go.tmpl", map[string]any{
-		"Name":        b.cfg.Name,
-		"PackageName": b.cfg.PkgName,
-		"Module":      b.cfg.Module,
-		"Version":     b.spec.Info.Version,
-	}); err != nil {
-		return fmt.Errorf("generate client: %w", err)
+	return nil
+}
+
+// writeRuntimePackages writes the packages that the generated code depends on. Unlike base files,
+// these are not meant to be modified and are regenerated on every build.
+func (b *Builder) writeRuntimePackages(outDir string) error {
+	for _, file := range []struct {
+		source      string
+		destination string
+	}{
+		{
+			source:      "datetime.go",
+			destination: "datetime/datetime.go",
+		},
+		{
+			source:      "nullable.go",
+			destination: "nullable/nullable.go",
+		},
+		{
+			source:      "secret.go",
+			destination: "secret/secret.go",
+		},
+	} {
+		dest := filepath.Join(outDir, file.destination)
+		if err := os.MkdirAll(path.Dir(dest), os.ModePerm); err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(dest, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(0o755))
+		if err != nil {
+			return fmt.Errorf("create %q: %w", dest, err)
+		}
+
+		if err := b.templates.ExecuteTemplate(f, fmt.Sprintf("%s.tmpl", file.source), map[string]any{
+			"PackageName": b.cfg.PkgName,
+			"Module":      b.cfg.Module,
+			"Name":        b.cfg.Name,
+		}); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("generate %q: %w", dest, err)
+		}
+
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("close file %q: %w", dest, err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,242 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Pagination styles supported by the `x-pagination` extension.
+const (
+	// paginationCursor passes cursor from the response to the next request.
+	paginationCursor = "cursor"
+	// paginationOffset increments the offset parameter by the number of items in the response.
+	paginationOffset = "offset"
+	// paginationNextLink follows a link to the next page from the response.
+	paginationNextLink = "next_link"
+)
+
+// paginationConfig is the configuration of pagination of an operation, as specified
+// by the `x-pagination` extension:
+//
+//	x-pagination:
+//	  style: cursor      # one of cursor, offset or next_link
+//	  items: items       # property of the response holding the items
+//	  param: cursor      # query parameter with the cursor (cursor) or offset (offset)
+//	  next: next_cursor  # property of the response with the next cursor (cursor) or link (next_link)
+//	  limit: limit       # query parameter with the page size (offset, optional)
+type paginationConfig struct {
+	Style string `json:"style"`
+	Items string `json:"items"`
+	Param string `json:"param"`
+	Next  string `json:"next"`
+	Limit string `json:"limit"`
+}
+
+// Pagination describes how to iterate over the pages of a list operation.
+type Pagination struct {
+	// ItemType is the type of the items of the pages.
+	ItemType string
+	// Items is the name of the response field holding the items.
+	Items string
+	// Next holds the statements that advance `params` (or `pageOpts`) to the page after `page`,
+	// returning if there are no more pages.
+	Next string
+}
+
+// pagination returns the pagination of the operation, nil if the operation is not paginated.
+func (b *Builder) pagination(o *openapi3.Operation, respType *ResponseType) (*Pagination, error) {
+	ext, ok := o.Extensions["x-pagination"]
+	if !ok {
+		return nil, nil
+	}
+
+	var cfg paginationConfig
+	data, err := json.Marshal(ext)
+	if err != nil {
+		return nil, fmt.Errorf("encode x-pagination: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("decode x-pagination: %w", err)
+	}
+
+	if respType == nil || respType.IsOneOf {
+		return nil, fmt.Errorf("paginated operation must have single success response")
+	}
+
+	schema := b.successResponseSchema(o)
+	if schema == nil || schema.Value == nil {
+		return nil, fmt.Errorf("paginated operation must have JSON success response")
+	}
+
+	fields, _ := b.createFields(allOfProperties(schema.Value), strings.TrimPrefix(respType.Type, "shared."), allOfRequired(schema.Value))
+	responseField := func(name string) (StructField, error) {
+		for _, f := range fields {
+			if f.Name == name {
+				return f, nil
+			}
+		}
+		return StructField{}, fmt.Errorf("response has no property %q", name)
+	}
+
+	items, err := responseField(cfg.Items)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(items.Type, "[]") || items.Nullable {
+		return nil, fmt.Errorf("items property %q must be an array", cfg.Items)
+	}
+
+	params, _ := b.paramFields(o)
+	queryParam := func(name string) (StructField, error) {
+		for _, f := range params {
+			if f.Parameter.In == "query" && f.Parameter.Name == name {
+				return f, nil
+			}
+		}
+		return StructField{}, fmt.Errorf("operation has no query parameter %q", name)
+	}
+
+	pagination := &Pagination{
+		ItemType: strings.TrimPrefix(items.Type, "[]"),
+		Items:    items.GoName(),
+	}
+
+	var next strings.Builder
+	switch cfg.Style {
+	case paginationCursor:
+		param, err := queryParam(cfg.Param)
+		if err != nil {
+			return nil, err
+		}
+		cursor, err := responseField(cfg.Next)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Type != param.Type {
+			return nil, fmt.Errorf("type of cursor %q (%s) doesn't match type of parameter %q (%s)", cfg.Next, cursor.Type, cfg.Param, param.Type)
+		}
+
+		writeNextValue(&next, cursor)
+		if param.Pointer {
+			fmt.Fprintf(&next, "params.%s = &next", param.GoName())
+		} else {
+			fmt.Fprintf(&next, "params.%s = next", param.GoName())
+		}
+	case paginationOffset:
+		param, err := queryParam(cfg.Param)
+		if err != nil {
+			return nil, err
+		}
+		if !isIntegerParam(param) {
+			return nil, fmt.Errorf("offset parameter %q must be an integer", cfg.Param)
+		}
+
+		fmt.Fprintf(&next, "if len(page.%s) == 0 {\n\treturn\n}\n", pagination.Items)
+		if cfg.Limit != "" {
+			limit, err := queryParam(cfg.Limit)
+			if err != nil {
+				return nil, err
+			}
+			if !isIntegerParam(limit) {
+				return nil, fmt.Errorf("limit parameter %q must be an integer", cfg.Limit)
+			}
+
+			if limit.Pointer {
+				fmt.Fprintf(&next, "if params.%[1]s != nil && len(page.%[2]s) < int(*params.%[1]s) {\n\treturn\n}\n", limit.GoName(), pagination.Items)
+			} else {
+				fmt.Fprintf(&next, "if len(page.%[2]s) < int(params.%[1]s) {\n\treturn\n}\n", limit.GoName(), pagination.Items)
+			}
+		}
+
+		if param.Type == "int" {
+			fmt.Fprintf(&next, "offset := len(page.%s)\n", pagination.Items)
+		} else {
+			fmt.Fprintf(&next, "offset := %s(len(page.%s))\n", param.Type, pagination.Items)
+		}
+		if param.Pointer {
+			fmt.Fprintf(&next, "if params.%[1]s != nil {\n\toffset += *params.%[1]s\n}\n", param.GoName())
+			fmt.Fprintf(&next, "params.%s = &offset", param.GoName())
+		} else {
+			fmt.Fprintf(&next, "params.%s += offset", param.GoName())
+		}
+	case paginationNextLink:
+		link, err := responseField(cfg.Next)
+		if err != nil {
+			return nil, err
+		}
+		if link.Schema == nil || link.Schema.Value == nil || !link.Schema.Value.Type.Includes("string") {
+			return nil, fmt.Errorf("next link property %q must be a string", cfg.Next)
+		}
+
+		writeNextValue(&next, link)
+		fmt.Fprint(&next, "pageOpts = append(opts[:len(opts):len(opts)], client.WithURL(next))")
+	default:
+		return nil, fmt.Errorf("unsupported pagination style %q", cfg.Style)
+	}
+
+	pagination.Next = next.String()
+	return pagination, nil
+}
+
+// writeNextValue writes statements that declare `next` variable holding the value
+// of the response field, returning if the value is not set.
+func writeNextValue(buf *strings.Builder, f StructField) {
+	var unset []string
+	switch {
+	case f.Nullable:
+		fmt.Fprintf(buf, "next, ok := page.%s.Get()\n", f.GoName())
+		unset = append(unset, "!ok")
+	case f.Pointer:
+		fmt.Fprintf(buf, "if page.%[1]s == nil {\n\treturn\n}\nnext := *page.%[1]s\n", f.GoName())
+	default:
+		fmt.Fprintf(buf, "next := page.%s\n", f.GoName())
+	}
+
+	if f.Schema != nil && f.Schema.Value != nil {
+		switch {
+		case f.Schema.Value.Type.Includes("string"):
+			unset = append(unset, `next == ""`)
+		case f.Schema.Value.Type.Includes("integer"):
+			unset = append(unset, "next == 0")
+		}
+	}
+
+	if len(unset) != 0 {
+		fmt.Fprintf(buf, "if %s {\n\treturn\n}\n", strings.Join(unset, " || "))
+	}
+}
+
+// isIntegerParam reports whether the parameter field is an integer.
+func isIntegerParam(f StructField) bool {
+	schema := paramFieldSchema(f)
+	return schema != nil && schema.Value != nil && schema.Value.Type.Is("integer")
+}
+
+// successResponseSchema returns the schema of the only successful JSON response of the operation,
+// nil if the operation has no or multiple successful JSON responses.
+func (b *Builder) successResponseSchema(o *openapi3.Operation) *openapi3.SchemaRef {
+	var schema *openapi3.SchemaRef
+	for code, response := range o.Responses.Map() {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+
+		if response.Ref != "" {
+			response = b.spec.Components.Responses[strings.TrimPrefix(response.Ref, "#/components/responses/")]
+		}
+
+		_, content := decodedMediaType(response.Value.Content)
+		if content == nil || content.Schema == nil {
+			continue
+		}
+		if schema != nil {
+			return nil
+		}
+		schema = content.Schema
+	}
+
+	return schema
+}
@@ -0,0 +1,73 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestPagination(t *testing.T) {
+	operation := func(pagination map[string]any) *openapi3.Operation {
+		responses := openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: &openapi3.Response{
+			Content: openapi3.NewContentWithJSONSchema(&openapi3.Schema{
+				Type:     &openapi3.Types{"object"},
+				Required: []string{"items"},
+				Properties: openapi3.Schemas{
+					"items":       openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()).NewRef(),
+					"next_cursor": openapi3.NewStringSchema().NewRef(),
+				},
+			}),
+		}}))
+		responses.Delete("default")
+
+		return &openapi3.Operation{
+			OperationID: "listCards",
+			Extensions:  map[string]any{"x-pagination": pagination},
+			Parameters: openapi3.Parameters{
+				{Value: openapi3.NewQueryParameter("cursor").WithSchema(openapi3.NewStringSchema())},
+				{Value: openapi3.NewQueryParameter("offset").WithSchema(openapi3.NewIntegerSchema())},
+			},
+			Responses: responses,
+		}
+	}
+	b := &Builder{spec: &openapi3.T{}}
+	respType := &ResponseType{Type: "ListCards200Response"}
+
+	pagination, err := b.pagination(operation(map[string]any{
+		"style": "cursor",
+		"items": "items",
+		"param": "cursor",
+		"next":  "next_cursor",
+	}), respType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pagination.ItemType != "string" || pagination.Items != "Items" {
+		t.Fatalf("unexpected items: %+v", pagination)
+	}
+	wantNext := "if page.NextCursor == nil {\n\treturn\n}\nnext := *page.NextCursor\nif next == \"\" {\n\treturn\n}\nparams.Cursor = &next"
+	if pagination.Next != wantNext {
+		t.Fatalf("unexpected next statements:\n got: %s\nwant: %s", pagination.Next, wantNext)
+	}
+
+	_, err = b.pagination(operation(map[string]any{
+		"style": "cursor",
+		"items": "items",
+		"param": "offset",
+		"next":  "next_cursor",
+	}), respType)
+	if err == nil || !strings.Contains(err.Error(), "doesn't match type") {
+		t.Fatalf("expected type mismatch error, got %v", err)
+	}
+
+	_, err = b.pagination(operation(map[string]any{"style": "page", "items": "items"}), respType)
+	if err == nil || !strings.Contains(err.Error(), "unsupported pagination style") {
+		t.Fatalf("expected unsupported style error, got %v", err)
+	}
+
+	if pagination, err := b.pagination(&openapi3.Operation{}, respType); pagination != nil || err != nil {
+		t.Fatalf("expected no pagination, got %+v, %v", pagination, err)
+	}
+}
@@ -0,0 +1,270 @@
+package builder
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// toQueryValues generates method that converts query parameters into [url.Values].
+type toQueryValues struct {
+	Typ *TypeDeclaration
+}
+
+func (e toQueryValues) String() string {
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "// QueryValues converts [%s] into [url.Values].\n", e.Typ.Name)
+	fmt.Fprintf(buf, "func (p *%s) QueryValues() url.Values {\n", e.Typ.Name)
+	fmt.Fprintf(buf, "\tq := make(url.Values)\n\n")
+	for _, f := range paramFieldsIn(e.Typ, "query") {
+		writeParam(buf, f, func(key, value string) string {
+			return fmt.Sprintf("q.Add(%q, %s)", key, value)
+		})
+		fmt.Fprint(buf, "\n")
+	}
+	fmt.Fprintf(buf, "\treturn q\n")
+	fmt.Fprint(buf, "}\n")
+	return buf.String()
+}
+
+// toFormValues generates method that converts application/x-www-form-urlencoded body into [url.Values].
+// The properties of the body are serialized the same way as query parameters, see [Builder.formFields].
+type toFormValues struct {
+	Typ *TypeDeclaration
+}
+
+func (e toFormValues) String() string {
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "// FormValues converts [%s] into [url.Values] of form-urlencoded body.\n", e.Typ.Name)
+	fmt.Fprintf(buf, "func (p *%s) FormValues() url.Values {\n", e.Typ.Name)
+	fmt.Fprintf(buf, "\tf := make(url.Values)\n\n")
+	for _, f := range e.Typ.Fields {
+		writeParam(buf, f, func(key, value string) string {
+			return fmt.Sprintf("f.Add(%q, %s)", key, value)
+		})
+		fmt.Fprint(buf, "\n")
+	}
+	fmt.Fprintf(buf, "\treturn f\n")
+	fmt.Fprint(buf, "}\n")
+	return buf.String()
+}
+
+// toHeaders generates method that converts header parameters into [http.Header].
+type toHeaders struct {
+	Typ *TypeDeclaration
+}
+
+func (e toHeaders) String() string {
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "// Headers converts header parameters of [%s] into [http.Header].\n", e.Typ.Name)
+	fmt.Fprintf(buf, "func (p *%s) Headers() http.Header {\n", e.Typ.Name)
+	fmt.Fprintf(buf, "\th := make(http.Header)\n\n")
+	for _, f := range paramFieldsIn(e.Typ, "header") {
+		writeParam(buf, f, func(key, value string) string {
+			return fmt.Sprintf("h.Set(%q, %s)", key, value)
+		})
+		fmt.Fprint(buf, "\n")
+	}
+	fmt.Fprintf(buf, "\treturn h\n")
+	fmt.Fprint(buf, "}\n")
+	return buf.String()
+}
+
+// toCookies generates method that converts cookie parameters into [http.Cookie]s.
+type toCookies struct {
+	Typ *TypeDeclaration
+}
+
+func (e toCookies) String() string {
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "// Cookies converts cookie parameters of [%s] into [http.Cookie]s.\n", e.Typ.Name)
+	fmt.Fprintf(buf, "func (p *%s) Cookies() []*http.Cookie {\n", e.Typ.Name)
+	fmt.Fprintf(buf, "\tcookies := make([]*http.Cookie, 0)\n\n")
+	for _, f := range paramFieldsIn(e.Typ, "cookie") {
+		writeParam(buf, f, func(key, value string) string {
+			return fmt.Sprintf("cookies = append(cookies, &http.Cookie{Name: %q, Value: %s})", key, value)
+		})
+		fmt.Fprint(buf, "\n")
+	}
+	fmt.Fprintf(buf, "\treturn cookies\n")
+	fmt.Fprint(buf, "}\n")
+	return buf.String()
+}
+
+// paramFieldsIn returns fields of parameters struct that are located in `in`,
+// e.g. "query" or "header".
+func paramFieldsIn(typ *TypeDeclaration, in string) []StructField {
+	fields := make([]StructField, 0, len(typ.Fields))
+	for _, f := range typ.Fields {
+		if f.Parameter != nil && f.Parameter.In == in {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// paramSeparator returns the separator of values of non-exploded parameters
+// serialized using the given style.
+func paramSeparator(style string) string {
+	switch style {
+	case openapi3.SerializationSpaceDelimited:
+		return " "
+	case openapi3.SerializationPipeDelimited:
+		return "|"
+	default:
+		return ","
+	}
+}
+
+// writeParam writes statements that serialize the parameter according to its `style`
+// and `explode` (see https://spec.openapis.org/oas/v3.1.0#style-values). The statements
+// are created by add from the key and the string representation of the value and are
+// executed only for values that are set. Exploded arrays and objects call add for each
+// item (e.g. `id=1&id=2` or `filter[status]=paid`), otherwise the values are joined
+// into a single value (e.g. `id=1,2` or `id=1|2`).
+func writeParam(buf *strings.Builder, f StructField, add func(key, value string) string) {
+	method, err := f.Parameter.SerializationMethod()
+	if err != nil {
+		slog.Warn("unsupported parameter serialization",
+			slog.String("name", f.Parameter.Name),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	name := f.Parameter.Name
+	field := "p." + f.GoName()
+	schema := dereferenceSchema(f.Parameter.Schema)
+
+	switch {
+	case len(f.Properties) != 0:
+		writeObjectParam(buf, field, f, method, add)
+	case schema.Value.Type.Is("array") && method.Explode && method.Style != openapi3.SerializationSimple:
+		writeParamValue(buf, "\t", field, f, func(value string) string {
+			return add(name, value)
+		})
+	case schema.Value.Type.Is("array"):
+		fmt.Fprintf(buf, "\tif len(%s) != 0 {\n", field)
+		fmt.Fprintf(buf, "\t\tvalues := make([]string, 0, len(%s))\n", field)
+		writeParamValue(buf, "\t\t", field, f, func(value string) string {
+			return fmt.Sprintf("values = append(values, %s)", value)
+		})
+		fmt.Fprintf(buf, "\t\t%s\n", add(name, fmt.Sprintf("strings.Join(values, %q)", paramSeparator(method.Style))))
+		fmt.Fprint(buf, "\t}\n")
+	default:
+		writeParamValue(buf, "\t", field, f, func(value string) string {
+			return add(name, value)
+		})
+	}
+}
+
+// writeObjectParam writes statements that serialize object parameter property by property.
+// Properties of `deepObject` and exploded `form` parameters are serialized as separate values,
+// properties of other objects are joined into a single value.
+func writeObjectParam(buf *strings.Builder, field string, f StructField, method *openapi3.SerializationMethod, add func(key, value string) string) {
+	indent := "\t"
+	if f.Pointer {
+		fmt.Fprintf(buf, "\tif %s != nil {\n", field)
+		indent += "\t"
+	}
+
+	separate := method.Style == openapi3.SerializationDeepObject ||
+		(method.Style == openapi3.SerializationForm && method.Explode)
+	if !separate {
+		fmt.Fprintf(buf, "%svalues := make([]string, 0)\n", indent)
+	}
+
+	for _, property := range f.Properties {
+		if isObjectSchema(property.Schema.Value) {
+			slog.Warn("nested objects in parameters are not supported",
+				slog.String("parameter", f.Parameter.Name),
+				slog.String("property", property.Name),
+			)
+			continue
+		}
+
+		access := field + "." + property.GoName()
+		writeParamValue(buf, indent, access, property, func(value string) string {
+			switch {
+			case method.Style == openapi3.SerializationDeepObject:
+				return add(fmt.Sprintf("%s[%s]", f.Parameter.Name, property.Name), value)
+			case separate:
+				return add(property.Name, value)
+			case method.Explode:
+				return fmt.Sprintf("values = append(values, %q+%s)", property.Name+"=", value)
+			default:
+				return fmt.Sprintf("values = append(values, %q, %s)", property.Name, value)
+			}
+		})
+	}
+
+	if !separate {
+		fmt.Fprintf(buf, "%s%s\n", indent, add(f.Parameter.Name, fmt.Sprintf("strings.Join(values, %q)", paramSeparator(method.Style))))
+	}
+
+	if f.Pointer {
+		fmt.Fprint(buf, "\t}\n")
+	}
+}
+
+// writeParamValue writes statement, created by emit from the string representation of
+// the value of the field, that is executed only if the field is set. For arrays, the
+// statement is written for each item.
+func writeParamValue(buf *strings.Builder, indent, access string, f StructField, emit func(value string) string) {
+	schema := paramFieldSchema(f)
+	if schema == nil || schema.Value == nil {
+		fmt.Fprintf(buf, "%s%s\n", indent, emit(access))
+		return
+	}
+
+	switch {
+	case f.Nullable:
+		fmt.Fprintf(buf, "%sif v, ok := %s.Get(); ok {\n", indent, access)
+		writeParamValue(buf, indent+"\t", "v", StructField{Schema: schema}, emit)
+		fmt.Fprintf(buf, "%s}\n", indent)
+	case schema.Value.Type.Is("array"):
+		item := &openapi3.Parameter{Schema: schema.Value.Items}
+		fmt.Fprintf(buf, "%sfor _, v := range %s {\n", indent, access)
+		fmt.Fprintf(buf, "%s\t%s\n", indent, emit(paramToString("v", item)))
+		fmt.Fprintf(buf, "%s}\n", indent)
+	case f.Pointer:
+		fmt.Fprintf(buf, "%sif %s != nil {\n", indent, access)
+		fmt.Fprintf(buf, "%s\t%s\n", indent, emit(paramToString("*"+access, &openapi3.Parameter{Schema: schema})))
+		fmt.Fprintf(buf, "%s}\n", indent)
+	default:
+		fmt.Fprintf(buf, "%s%s\n", indent, emit(paramToString(access, &openapi3.Parameter{Schema: schema})))
+	}
+}
+
+// paramFieldSchema returns schema of the parameter or of the property of object parameter.
+func paramFieldSchema(f StructField) *openapi3.SchemaRef {
+	if f.Parameter != nil {
+		return dereferenceSchema(f.Parameter.Schema)
+	}
+	return dereferenceSchema(f.Schema)
+}
+
+// formFields returns the fields of application/x-www-form-urlencoded body. Each property is treated
+// as a query parameter serialized according to its `encoding` (`form` style, exploded by default).
+// The JSON tags are removed as the body is not encoded as JSON.
+func (b *Builder) formFields(fields []StructField, encoding map[string]*openapi3.Encoding) []StructField {
+	converted := make([]StructField, 0, len(fields))
+	for _, f := range fields {
+		param := &openapi3.Parameter{Name: f.Name, In: openapi3.ParameterInQuery, Schema: f.Schema}
+		if enc, ok := encoding[f.Name]; ok && enc != nil {
+			param.Style = enc.Style
+			param.Explode = enc.Explode
+		}
+
+		if schema := dereferenceSchema(f.Schema); schema != nil && isObjectSchema(schema.Value) {
+			f.Properties, _ = b.createFields(allOfProperties(schema.Value), f.Type, allOfRequired(schema.Value))
+		}
+
+		f.Tags = nil
+		f.Parameter = param
+		converted = append(converted, f)
+	}
+	return converted
+}
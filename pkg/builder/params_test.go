@@ -0,0 +1,99 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestWriteParam(t *testing.T) {
+	integer := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}}
+	array := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: integer}}
+	object := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:       &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{"amount": integer},
+	}}
+	amount := StructField{Name: "amount", Schema: integer, Pointer: true}
+	explode, exploded := false, true
+
+	for name, tc := range map[string]struct {
+		field StructField
+		want  []string
+	}{
+		"prefixed name": {
+			field: StructField{Name: "$filter", Pointer: true, Parameter: &openapi3.Parameter{Name: "$filter", In: "query", Schema: integer}},
+			want:  []string{`if p.Filter != nil {`, `add("$filter", strconv.Itoa(*p.Filter))`},
+		},
+		"prefixed property": {
+			field: StructField{Name: "filter", Pointer: true, Properties: []StructField{{Name: "+amount", Schema: integer, Pointer: true}}, Parameter: &openapi3.Parameter{Name: "filter", In: "query", Style: "deepObject", Schema: object}},
+			want:  []string{`if p.Filter.Plusamount != nil {`},
+		},
+		"exploded form array": {
+			field: StructField{Name: "ids", Parameter: &openapi3.Parameter{Name: "ids", In: "query", Schema: array}},
+			want:  []string{`add("ids", strconv.Itoa(v))`},
+		},
+		"pipe delimited array": {
+			field: StructField{Name: "ids", Parameter: &openapi3.Parameter{Name: "ids", In: "query", Style: "pipeDelimited", Explode: &explode, Schema: array}},
+			want:  []string{`values = append(values, strconv.Itoa(v))`, `add("ids", strings.Join(values, "|"))`},
+		},
+		"deep object": {
+			field: StructField{Name: "filter", Pointer: true, Properties: []StructField{amount}, Parameter: &openapi3.Parameter{Name: "filter", In: "query", Style: "deepObject", Schema: object}},
+			want:  []string{`if p.Filter.Amount != nil {`, `add("filter[amount]", strconv.Itoa(*p.Filter.Amount))`},
+		},
+		"form object": {
+			field: StructField{Name: "filter", Pointer: true, Properties: []StructField{amount}, Parameter: &openapi3.Parameter{Name: "filter", In: "query", Explode: &explode, Schema: object}},
+			want:  []string{`values = append(values, "amount", strconv.Itoa(*p.Filter.Amount))`, `add("filter", strings.Join(values, ","))`},
+		},
+		"simple exploded object": {
+			field: StructField{Name: "filter", Pointer: true, Properties: []StructField{amount}, Parameter: &openapi3.Parameter{Name: "X-Filter", In: "header", Explode: &exploded, Schema: object}},
+			want:  []string{`values = append(values, "amount="+strconv.Itoa(*p.Filter.Amount))`},
+		},
+	} {
+		buf := new(strings.Builder)
+		writeParam(buf, tc.field, func(key, value string) string {
+			return fmt.Sprintf("add(%q, %s)", key, value)
+		})
+		for _, want := range tc.want {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%s: expected %q in:\n%s", name, want, buf.String())
+			}
+		}
+	}
+}
+
+func TestFormValues(t *testing.T) {
+	b := &Builder{}
+	stringArray := openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()).NewRef()
+	explode := false
+	fields := b.formFields([]StructField{
+		{Name: "scope", Type: "[]string", Schema: stringArray, Tags: map[string][]string{"json": {"scope"}}},
+		{Name: "tags", Type: "[]string", Schema: stringArray},
+		{
+			Name:    "metadata",
+			Type:    "Metadata",
+			Pointer: true,
+			Schema:  openapi3.NewObjectSchema().WithProperty("device", openapi3.NewStringSchema()).NewRef(),
+		},
+	}, map[string]*openapi3.Encoding{
+		"scope":    {Style: openapi3.SerializationSpaceDelimited, Explode: &explode},
+		"metadata": {Style: openapi3.SerializationDeepObject},
+	})
+
+	got := toFormValues{Typ: &TypeDeclaration{Name: "TokenBody", Fields: fields}}.String()
+	for _, want := range []string{
+		"func (p *TokenBody) FormValues() url.Values {",
+		`f.Add("scope", strings.Join(values, " "))`,
+		`f.Add("tags", v)`,
+		`f.Add("metadata[device]", *p.Metadata.Device)`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %s in:\n%s", want, got)
+		}
+	}
+
+	if fields[0].Tags != nil {
+		t.Errorf("expected json tags to be removed, got %v", fields[0].Tags)
+	}
+}
@@ -0,0 +1,172 @@
+package builder
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/iancoleman/strcase"
+)
+
+// reservedClientOptions are the names of client options that are always generated
+// and can't be used for options of security schemes. `WithAPIKey` is kept as a deprecated
+// alias of the option of the default security scheme.
+var reservedClientOptions = []string{"WithClient", "WithBaseURL", "WithServer", "WithServerVariables", "WithUserAgent", "WithAPIKey"}
+
+// SecurityScheme describes a security scheme of the API that the client can use
+// to authorize requests.
+type SecurityScheme struct {
+	// Name of the scheme as defined in `components.securitySchemes`.
+	Name string
+	// Kind of the scheme, one of "apiKey", "basic", "bearer", or "oauth2".
+	Kind string
+	// Option is the name of the client option that configures credentials for the scheme.
+	Option string
+	// Description of the scheme.
+	Description string
+	// In is the location of the API key, one of "header", "query", or "cookie".
+	In string
+	// ParamName is the name of the header, query parameter, or cookie holding the API key.
+	ParamName string
+	// TokenURL is the token URL of OAuth 2.0 client credentials flow. Empty if the scheme
+	// doesn't support the client credentials flow.
+	TokenURL string
+}
+
+// securitySchemes returns the security schemes supported by the generated client,
+// sorted by name.
+func (b *Builder) securitySchemes() []SecurityScheme {
+	if b.spec.Components == nil {
+		return nil
+	}
+
+	names := slices.Sorted(maps.Keys(b.spec.Components.SecuritySchemes))
+	schemes := make([]SecurityScheme, 0, len(names))
+	for _, name := range names {
+		ref := b.spec.Components.SecuritySchemes[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+
+		spec := ref.Value
+		scheme := SecurityScheme{
+			Name:        name,
+			Option:      "With" + strcase.ToCamel(name),
+			Description: formatGodoc(strings.TrimSpace(spec.Description)),
+		}
+		if slices.Contains(reservedClientOptions, scheme.Option) {
+			scheme.Option += "Credentials"
+		}
+
+		switch {
+		case spec.Type == "apiKey":
+			scheme.Kind = "apiKey"
+			scheme.In = spec.In
+			scheme.ParamName = spec.Name
+		case spec.Type == "http" && strings.EqualFold(spec.Scheme, "basic"):
+			scheme.Kind = "basic"
+		case spec.Type == "http" && strings.EqualFold(spec.Scheme, "bearer"):
+			scheme.Kind = "bearer"
+		case spec.Type == "oauth2":
+			scheme.Kind = "oauth2"
+			if spec.Flows != nil && spec.Flows.ClientCredentials != nil {
+				scheme.TokenURL = spec.Flows.ClientCredentials.TokenURL
+			}
+		case spec.Type == "openIdConnect":
+			// the client is expected to obtain the access token on its own
+			scheme.Kind = "bearer"
+		default:
+			slog.Warn("unsupported security scheme, skipping",
+				slog.String("name", name),
+				slog.String("type", spec.Type),
+				slog.String("scheme", spec.Scheme),
+			)
+			continue
+		}
+
+		schemes = append(schemes, scheme)
+	}
+
+	return schemes
+}
+
+// defaultSecurityScheme returns the scheme that is configured from environment variable
+// by default. This is the first scheme that uses a single token (API key or bearer token),
+// preferring the schemes required by the API globally. Returns nil if there is no such scheme.
+func defaultSecurityScheme(schemes []SecurityScheme, global openapi3.SecurityRequirements) *SecurityScheme {
+	usesToken := func(s SecurityScheme) bool {
+		return s.Kind == "apiKey" || s.Kind == "bearer"
+	}
+
+	for _, requirement := range securityRequirements(&global) {
+		for _, name := range requirement {
+			idx := slices.IndexFunc(schemes, func(s SecurityScheme) bool {
+				return s.Name == name && usesToken(s)
+			})
+			if idx != -1 {
+				return &schemes[idx]
+			}
+		}
+	}
+
+	if idx := slices.IndexFunc(schemes, usesToken); idx != -1 {
+		return &schemes[idx]
+	}
+
+	return nil
+}
+
+// clientSecurity returns the security requirements of requests sent using `Client.Do`.
+// These are the requirements of the API or, if the API doesn't declare any, the default
+// security scheme.
+func clientSecurity(global openapi3.SecurityRequirements, defaultScheme *SecurityScheme) [][]string {
+	if requirements := securityRequirements(&global); len(requirements) != 0 {
+		return requirements
+	}
+	if defaultScheme != nil {
+		return [][]string{{defaultScheme.Name}}
+	}
+	return nil
+}
+
+// operationSecurity returns the security requirements of the operation. Operations that
+// don't declare security requirements inherit the requirements of the API.
+func (b *Builder) operationSecurity(o *openapi3.Operation) [][]string {
+	if o.Security != nil {
+		return securityRequirements(o.Security)
+	}
+	return securityRequirements(&b.spec.Security)
+}
+
+// securityRequirements converts security requirements into lists of names of the schemes
+// that need to be satisfied, in the order of preference.
+func securityRequirements(requirements *openapi3.SecurityRequirements) [][]string {
+	if requirements == nil || len(*requirements) == 0 {
+		return nil
+	}
+
+	res := make([][]string, 0, len(*requirements))
+	for _, requirement := range *requirements {
+		res = append(res, slices.Sorted(maps.Keys(requirement)))
+	}
+	return res
+}
+
+// securityRequirementsString returns Go code of [securityRequirements] passed to `client.WithSecurity`.
+func securityRequirementsString(requirements [][]string) string {
+	buf := new(strings.Builder)
+	for i, requirement := range requirements {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		quoted := make([]string, 0, len(requirement))
+		for _, name := range requirement {
+			quoted = append(quoted, fmt.Sprintf("%q", name))
+		}
+		fmt.Fprintf(buf, "client.SecurityRequirement{%s}", strings.Join(quoted, ", "))
+	}
+	return buf.String()
+}
@@ -0,0 +1,72 @@
+package builder
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestOperationSecurity(t *testing.T) {
+	b := &Builder{spec: &openapi3.T{
+		Security: openapi3.SecurityRequirements{
+			{"apiKey": {}},
+			{"oauth2": {"payments"}},
+		},
+	}}
+
+	inherited := b.operationSecurity(&openapi3.Operation{})
+	if got, want := securityRequirementsString(inherited), `client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"}`; got != want {
+		t.Fatalf("expected global requirements:\n got: %s\nwant: %s", got, want)
+	}
+
+	combined := b.operationSecurity(&openapi3.Operation{
+		Security: &openapi3.SecurityRequirements{{"merchantKey": {}, "basicAuth": {}}},
+	})
+	if got, want := securityRequirementsString(combined), `client.SecurityRequirement{"basicAuth", "merchantKey"}`; got != want {
+		t.Fatalf("expected operation requirements:\n got: %s\nwant: %s", got, want)
+	}
+
+	anonymous := b.operationSecurity(&openapi3.Operation{Security: &openapi3.SecurityRequirements{}})
+	if anonymous != nil {
+		t.Fatalf("expected no requirements for `security: []`, got %v", anonymous)
+	}
+}
+
+func TestDefaultSecurityScheme(t *testing.T) {
+	schemes := []SecurityScheme{
+		{Name: "apiKey", Kind: "bearer"},
+		{Name: "basicAuth", Kind: "basic"},
+		{Name: "queryKey", Kind: "apiKey"},
+	}
+
+	global := openapi3.SecurityRequirements{{"basicAuth": {}}, {"queryKey": {}}}
+	if got := defaultSecurityScheme(schemes, global); got == nil || got.Name != "queryKey" {
+		t.Fatalf("expected globally required token scheme, got %v", got)
+	}
+
+	if got := defaultSecurityScheme(schemes, nil); got == nil || got.Name != "apiKey" {
+		t.Fatalf("expected first token scheme, got %v", got)
+	}
+
+	if got := defaultSecurityScheme(schemes[1:2], nil); got != nil {
+		t.Fatalf("expected no default scheme, got %v", got)
+	}
+}
+
+func TestClientSecurity(t *testing.T) {
+	apiKey := &SecurityScheme{Name: "apiKey", Kind: "bearer"}
+
+	global := openapi3.SecurityRequirements{{"basicAuth": {}, "merchantKey": {}}, {"queryKey": {}}}
+	if got, want := clientSecurity(global, apiKey), [][]string{{"basicAuth", "merchantKey"}, {"queryKey"}}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("expected requirements of the API, got %v, want %v", got, want)
+	}
+
+	if got, want := clientSecurity(nil, apiKey), [][]string{{"apiKey"}}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("expected the default scheme, got %v, want %v", got, want)
+	}
+
+	if got := clientSecurity(nil, nil); got != nil {
+		t.Fatalf("expected no requirements, got %v", got)
+	}
+}
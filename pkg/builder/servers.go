@@ -0,0 +1,124 @@
+package builder
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/iancoleman/strcase"
+)
+
+var nonAlphanumericRegexp = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// Server describes a server of the API that the client can be configured to use.
+type Server struct {
+	// Name of the generated variable holding the server, e.g. `ServerProduction`.
+	Name string
+	// Description of the server.
+	Description string
+	// URL of the server, possibly with variables.
+	URL string
+	// Variables of the server URL, sorted by name.
+	Variables []ServerVariable
+}
+
+// ServerVariable is a variable of [Server] URL.
+type ServerVariable struct {
+	Name    string
+	Default string
+	Enum    []string
+}
+
+// servers returns the servers of the API. Servers are named after their description,
+// falling back to their position for servers without description.
+func (b *Builder) servers() []Server {
+	servers := make([]Server, 0, len(b.spec.Servers))
+	for i, server := range b.spec.Servers {
+		name := "Server" + strcase.ToCamel(nonAlphanumericRegexp.ReplaceAllString(server.Description, " "))
+		if name == "Server" || slices.ContainsFunc(servers, func(s Server) bool { return s.Name == name }) {
+			name = fmt.Sprintf("Server%d", i+1)
+		}
+
+		description := fmt.Sprintf("%s is the %s server.", name, server.URL)
+		if server.Description != "" {
+			description = fmt.Sprintf("%s: %s", name, strings.TrimSpace(server.Description))
+		}
+
+		servers = append(servers, Server{
+			Name:        name,
+			Description: formatGodoc(description),
+			URL:         server.URL,
+			Variables:   serverVariables(server),
+		})
+	}
+
+	return servers
+}
+
+// serverVariables returns the variables of the server URL, sorted by name.
+func serverVariables(server *openapi3.Server) []ServerVariable {
+	variables := make([]ServerVariable, 0, len(server.Variables))
+	for _, name := range slices.Sorted(maps.Keys(server.Variables)) {
+		variable := server.Variables[name]
+		variables = append(variables, ServerVariable{
+			Name:    name,
+			Default: variable.Default,
+			Enum:    variable.Enum,
+		})
+	}
+	return variables
+}
+
+// serverURL returns URL of the server with variables replaced by their default values.
+func serverURL(server *openapi3.Server) string {
+	u := server.URL
+	for name, variable := range server.Variables {
+		u = strings.ReplaceAll(u, "{"+name+"}", variable.Default)
+	}
+	return u
+}
+
+// serverOverride returns the server that the operation is sent to instead of the servers
+// of the API, or nil if the operation doesn't override the servers. Servers of the operation
+// take precedence over servers of the path.
+func serverOverride(path *openapi3.PathItem, o *openapi3.Operation) *Server {
+	var server *openapi3.Server
+	switch {
+	case o.Servers != nil && len(*o.Servers) != 0:
+		server = (*o.Servers)[0]
+	case len(path.Servers) != 0:
+		server = path.Servers[0]
+	default:
+		return nil
+	}
+
+	return &Server{
+		URL:       server.URL,
+		Variables: serverVariables(server),
+	}
+}
+
+// serverString returns Go code of the server passed to `client.WithOperationServer`.
+func serverString(server *Server) string {
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "client.Server{URL: %q", server.URL)
+	if len(server.Variables) != 0 {
+		buf.WriteString(", Variables: map[string]client.ServerVariable{")
+		for i, variable := range server.Variables {
+			if i != 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "%q: {Default: %q", variable.Name, variable.Default)
+			if len(variable.Enum) != 0 {
+				fmt.Fprintf(buf, ", Enum: []string{%s}", quoteAll(variable.Enum))
+			}
+			buf.WriteString("}")
+		}
+		buf.WriteString("}")
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
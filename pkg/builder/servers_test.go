@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestServers(t *testing.T) {
+	b := &Builder{spec: &openapi3.T{
+		Servers: openapi3.Servers{
+			{URL: "https://api.example.com", Description: "Production"},
+			{URL: "https://sandbox.example.com", Description: "Sandbox (EU)"},
+			{URL: "https://dev.example.com"},
+			{URL: "https://prod.example.com", Description: "Production"},
+		},
+	}}
+
+	want := []string{"ServerProduction", "ServerSandboxEu", "Server3", "Server4"}
+	servers := b.servers()
+	if len(servers) != len(want) {
+		t.Fatalf("expected %d servers, got %d", len(want), len(servers))
+	}
+	for i, server := range servers {
+		if server.Name != want[i] {
+			t.Errorf("server %d: got name %q, want %q", i, server.Name, want[i])
+		}
+	}
+}
+
+func TestServerOverride(t *testing.T) {
+	path := &openapi3.PathItem{Servers: openapi3.Servers{{URL: "https://files.example.com"}}}
+
+	if got := serverOverride(path, &openapi3.Operation{}); got == nil || got.URL != "https://files.example.com" {
+		t.Fatalf("expected path server, got %v", got)
+	}
+
+	operation := &openapi3.Operation{Servers: &openapi3.Servers{{
+		URL:       "https://{region}.example.com",
+		Variables: map[string]*openapi3.ServerVariable{"region": {Default: "eu", Enum: []string{"eu", "us"}}},
+	}}}
+	got := serverOverride(path, operation)
+	if got == nil {
+		t.Fatal("expected operation server")
+	}
+	want := `client.Server{URL: "https://{region}.example.com", Variables: map[string]client.ServerVariable{"region": {Default: "eu", Enum: []string{"eu", "us"}}}}`
+	if code := serverString(got); code != want {
+		t.Fatalf("unexpected server:\n got: %s\nwant: %s", code, want)
+	}
+
+	if got := serverOverride(&openapi3.PathItem{}, &openapi3.Operation{}); got != nil {
+		t.Fatalf("expected no override, got %v", got)
+	}
+}
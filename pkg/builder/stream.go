@@ -0,0 +1,200 @@
+package builder
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/iancoleman/strcase"
+)
+
+const (
+	// streamSSE are Server-Sent Events, see https://html.spec.whatwg.org/multipage/server-sent-events.html.
+	streamSSE = "sse"
+	// streamNDJSON is newline delimited JSON, see https://github.com/ndjson/ndjson-spec.
+	streamNDJSON = "ndjson"
+)
+
+// streamKind returns the kind of stream of the media type: [streamSSE] for `text/event-stream`,
+// [streamNDJSON] for `application/x-ndjson` and `application/jsonl`, and empty string for media
+// types that are not streamed.
+func streamKind(mediaType string) string {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return ""
+	}
+
+	switch parsed {
+	case "text/event-stream":
+		return streamSSE
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return streamNDJSON
+	default:
+		return ""
+	}
+}
+
+// streamMediaType returns the first (in alphabetical order) streamed media type of the content
+// along with its name, nil if the content has no streamed media type.
+func streamMediaType(content openapi3.Content) (string, *openapi3.MediaType) {
+	for _, name := range slices.Sorted(maps.Keys(content)) {
+		if streamKind(name) != "" {
+			return name, content[name]
+		}
+	}
+	return "", nil
+}
+
+// streamItemName returns the name of the type generated for inline schema of the items
+// of streamed response of the operation.
+func streamItemName(operationName string) string {
+	return operationName + "Item"
+}
+
+// streamItemType returns the Go type of the items of streamed response of the operation, see
+// [Builder.streamItemSchema]. Items without schema are returned as raw data.
+func (b *Builder) streamItemType(operationName, kind string, mt *openapi3.MediaType) string {
+	schema := b.streamItemSchema(kind, mt)
+	switch {
+	case schema != nil:
+		return b.convertToValidGoType(streamItemName(operationName), schema)
+	case kind == streamSSE:
+		return "string"
+	default:
+		return "json.RawMessage"
+	}
+}
+
+// streamItemSchema returns the schema of the items of streamed media type: the `itemSchema` (OpenAPI 3.2)
+// or the `schema` of the media type, using the items of array schemas. The stream of Server-Sent Events
+// yields the data of the events, if the schema describes the whole event (object with `data` property
+// along with `event`, `id` or `retry`), the schema of the `data` property is returned.
+func (b *Builder) streamItemSchema(kind string, mt *openapi3.MediaType) *openapi3.SchemaRef {
+	schema := b.itemSchema(mt)
+	if schema == nil {
+		schema = mt.Schema
+		if schema != nil && schema.Value != nil && isArraySchema(schema.Value) && schema.Value.Items != nil {
+			schema = schema.Value.Items
+		}
+	}
+	if schema == nil || schema.Value == nil {
+		return nil
+	}
+
+	if kind == streamSSE {
+		props := schema.Value.Properties
+		if data, ok := props["data"]; ok && (props["event"] != nil || props["id"] != nil || props["retry"] != nil) {
+			return data
+		}
+	}
+
+	return schema
+}
+
+// itemSchema returns the `itemSchema` of the media type (OpenAPI 3.2), nil if not set. kin-openapi
+// doesn't support `itemSchema` yet and keeps it in the extensions as is, so the references are resolved
+// against the component schemas here.
+func (b *Builder) itemSchema(mt *openapi3.MediaType) *openapi3.SchemaRef {
+	raw, ok := mt.Extensions["itemSchema"]
+	if !ok {
+		return nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		slog.Warn("invalid itemSchema", slog.String("error", err.Error()))
+		return nil
+	}
+
+	var schema openapi3.SchemaRef
+	if err := json.Unmarshal(data, &schema); err != nil {
+		slog.Warn("invalid itemSchema", slog.String("error", err.Error()))
+		return nil
+	}
+
+	b.resolveComponentRefs(&schema)
+	return &schema
+}
+
+// resolveComponentRefs sets the values of references to component schemas in the schema.
+func (b *Builder) resolveComponentRefs(schema *openapi3.SchemaRef) {
+	if schema == nil {
+		return
+	}
+
+	if schema.Ref != "" {
+		if component, ok := b.spec.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]; ok {
+			schema.Value = component.Value
+		} else {
+			slog.Warn("unresolved schema reference", slog.String("ref", schema.Ref))
+		}
+		return
+	}
+
+	if schema.Value == nil {
+		return
+	}
+
+	for _, prop := range schema.Value.Properties {
+		b.resolveComponentRefs(prop)
+	}
+	b.resolveComponentRefs(schema.Value.Items)
+	b.resolveComponentRefs(schema.Value.AdditionalProperties.Schema)
+	for _, members := range []openapi3.SchemaRefs{schema.Value.AllOf, schema.Value.OneOf, schema.Value.AnyOf} {
+		for _, member := range members {
+			b.resolveComponentRefs(member)
+		}
+	}
+}
+
+// streamItemTypes generates the type of the items of streamed content, if the items have inline schema.
+func (b *Builder) streamItemTypes(operationName string, content openapi3.Content) []Writable {
+	name, mt := streamMediaType(content)
+	if mt == nil {
+		return nil
+	}
+
+	schema := b.streamItemSchema(streamKind(name), mt)
+	if schema == nil || schema.Ref != "" {
+		return nil
+	}
+
+	itemName := streamItemName(operationName)
+	if b.convertToValidGoType(itemName, schema) != itemName {
+		// primitive types don't need type declaration
+		return nil
+	}
+
+	return b.generateSchemaComponents(itemName, schema, false)
+}
+
+// streamSuccessResponseType returns the type of the successful response of the operation with
+// streamed content, nil if there is no such response.
+func (b *Builder) streamSuccessResponseType(o *openapi3.Operation) *ResponseType {
+	responses := o.Responses.Map()
+	for _, code := range slices.Sorted(maps.Keys(responses)) {
+		response := responses[code]
+		if !strings.HasPrefix(code, "2") || response.Value == nil {
+			continue
+		}
+
+		name, mt := streamMediaType(response.Value.Content)
+		if mt == nil {
+			continue
+		}
+
+		kind := streamKind(name)
+		item := b.streamItemType(strcase.ToCamel(o.OperationID), kind, mt)
+		return &ResponseType{
+			Type:     "client.Stream[" + item + "]",
+			Stream:   kind,
+			ItemType: item,
+		}
+	}
+
+	return nil
+}
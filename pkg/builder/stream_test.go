@@ -0,0 +1,83 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestStreamKind(t *testing.T) {
+	for mediaType, want := range map[string]string{
+		"text/event-stream":                 streamSSE,
+		"text/event-stream; charset=utf-8":  streamSSE,
+		"application/x-ndjson":              streamNDJSON,
+		"application/jsonl":                 streamNDJSON,
+		"application/json":                  "",
+		"application/vnd.codegen.card+json": "",
+	} {
+		if got := streamKind(mediaType); got != want {
+			t.Errorf("streamKind(%q) = %q, want %q", mediaType, got, want)
+		}
+	}
+}
+
+func TestStreamItemType(t *testing.T) {
+	event := openapi3.NewObjectSchema().WithProperty("id", openapi3.NewStringSchema())
+	b := &Builder{spec: &openapi3.T{Components: &openapi3.Components{
+		Schemas: openapi3.Schemas{"Event": event.NewRef()},
+	}}}
+
+	tests := []struct {
+		name string
+		kind string
+		mt   *openapi3.MediaType
+		want string
+	}{
+		{
+			name: "item schema of event",
+			kind: streamSSE,
+			mt: &openapi3.MediaType{Extensions: map[string]any{
+				"itemSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"id":   map[string]any{"type": "string"},
+						"data": map[string]any{"$ref": "#/components/schemas/Event"},
+					},
+				},
+			}},
+			want: "Event",
+		},
+		{
+			name: "data without schema",
+			kind: streamSSE,
+			mt:   &openapi3.MediaType{},
+			want: "string",
+		},
+		{
+			name: "items of array schema",
+			kind: streamNDJSON,
+			mt:   openapi3.NewMediaType().WithSchema(openapi3.NewArraySchema().WithItems(openapi3.NewIntegerSchema())),
+			want: "int",
+		},
+		{
+			name: "inline object",
+			kind: streamNDJSON,
+			mt:   openapi3.NewMediaType().WithSchema(event),
+			want: "StreamCardsItem",
+		},
+		{
+			name: "items without schema",
+			kind: streamNDJSON,
+			mt:   &openapi3.MediaType{},
+			want: "json.RawMessage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.streamItemType("StreamCards", tt.kind, tt.mt); got != tt.want {
+				t.Errorf("streamItemType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -34,7 +34,13 @@ func (b *Builder) respToTypes(schemas []*openapi3.ResponseRef, errorSchemas map[
 	for _, s := range schemas {
 		_, isErr := errorSchemas[s.Ref]
 		name := strcase.ToCamel(strings.TrimPrefix(s.Ref, "#/components/responses/")) + "Response"
-		if s.Value.Content == nil {
+		if isProblemResponse(s.Value) {
+			// decoded into client.Problem, see responseToType
+			continue
+		}
+
+		_, content := decodedMediaType(s.Value.Content)
+		if content == nil || content.Schema == nil {
 			if isErr {
 				allTypes = append(allTypes, typeAssertionDeclaration{
 					typ: name,
@@ -60,7 +66,7 @@ func (b *Builder) respToTypes(schemas []*openapi3.ResponseRef, errorSchemas map[
 			}
 			continue
 		}
-		typeTpl := b.generateSchemaComponents(name, s.Value.Content["application/json"].Schema, isErr)
+		typeTpl := b.generateSchemaComponents(name, content.Schema, isErr)
 		allTypes = append(allTypes, typeTpl...)
 	}
 
@@ -89,12 +95,32 @@ func (b *Builder) pathsToBodyTypes(paths *openapi3.Paths) []Writable {
 			operationName := strcase.ToCamel(opSpec.OperationID)
 
 			if opSpec.RequestBody != nil {
-				mt, ok := opSpec.RequestBody.Value.Content["application/json"]
-				if ok && mt.Schema != nil {
+				content := opSpec.RequestBody.Value.Content
+				mediaTypes := requestBodyMediaTypes(content)
+				if len(mediaTypes) != 0 {
 					name := operationName + "Body"
-					bodyObject, additionalTypes := b.createObject(mt.Schema.Value, name)
+					bodyObject, additionalTypes := b.createObject(content[mediaTypes[0]].Schema.Value, name)
 					paramTypes = append(paramTypes, bodyObject)
 					paramTypes = append(paramTypes, additionalTypes...)
+
+					fields := bodyObject.Fields
+					if slices.Contains(mediaTypes, mediaTypeMultipart) {
+						bodyObject.Fields = multipartFields(bodyObject.Fields)
+						paramTypes = append(paramTypes, &toMultipart{Typ: bodyObject, Encoding: content[mediaTypeMultipart].Encoding})
+					}
+					if slices.Contains(mediaTypes, mediaTypeForm) {
+						bodyObject.Fields = b.formFields(bodyObject.Fields, content[mediaTypeForm].Encoding)
+						paramTypes = append(paramTypes, &toFormValues{Typ: bodyObject})
+					}
+					if slices.ContainsFunc(mediaTypes, isXMLMediaType) {
+						paramTypes = append(paramTypes, newXMLRoot(bodyObject, content[mediaTypes[0]].Schema))
+					}
+					if slices.ContainsFunc(mediaTypes, isDecodedMediaType) {
+						// the body can be sent as JSON or XML as well, keep the tags
+						for i := range bodyObject.Fields {
+							bodyObject.Fields[i].Tags = fields[i].Tags
+						}
+					}
 				}
 			}
 		}
@@ -126,36 +152,8 @@ func (b *Builder) pathsToParamTypes(paths *openapi3.Paths) []Writable {
 			operationName := strcase.ToCamel(opSpec.OperationID)
 
 			if len(opSpec.Parameters) > 0 {
-				fields := make([]StructField, 0)
-				for _, p := range opSpec.Parameters {
-					// path parameters are passed as a parameters to the generated method
-					if p.Value.In == "path" {
-						continue
-					}
-
-					name := p.Value.Name
-					if p.Ref != "" {
-						name = strcase.ToCamel(strings.TrimPrefix(p.Ref, "#/components/schemas/"))
-					}
-
-					typ := b.convertToValidGoType("", p.Value.Schema)
-
-					isShared := slices.Contains(b.schemasByTag["shared"], p.Value.Schema.Ref)
-					if isShared && !strings.HasPrefix(typ, "shared.") {
-						typ = "shared." + typ
-					}
-
-					optional := !p.Value.Required
-					pointer := shouldUsePointer(optional, p.Value.Schema, typ)
-					fields = append(fields, StructField{
-						Name:      name,
-						Type:      typ,
-						Parameter: p.Value,
-						Optional:  optional,
-						Pointer:   pointer,
-						Comment:   parameterPropertyGodoc(p.Value),
-					})
-				}
+				fields, additionalTypes := b.paramFields(opSpec)
+				paramTypes = append(paramTypes, additionalTypes...)
 
 				if len(fields) != 0 {
 					paramsTypeName := operationName + "Params"
@@ -167,7 +165,16 @@ func (b *Builder) pathsToParamTypes(paths *openapi3.Paths) []Writable {
 						Operation: opSpec,
 					}
 
-					paramTypes = append(paramTypes, &paramsTpl, &toQueryValues{Typ: &paramsTpl})
+					paramTypes = append(paramTypes, &paramsTpl)
+					if len(paramFieldsIn(&paramsTpl, "query")) != 0 {
+						paramTypes = append(paramTypes, &toQueryValues{Typ: &paramsTpl})
+					}
+					if len(paramFieldsIn(&paramsTpl, "header")) != 0 {
+						paramTypes = append(paramTypes, &toHeaders{Typ: &paramsTpl})
+					}
+					if len(paramFieldsIn(&paramsTpl, "cookie")) != 0 {
+						paramTypes = append(paramTypes, &toCookies{Typ: &paramsTpl})
+					}
 				}
 			}
 		}
@@ -176,6 +183,66 @@ func (b *Builder) pathsToParamTypes(paths *openapi3.Paths) []Writable {
 	return paramTypes
 }
 
+// paramFields returns the fields of the parameters struct of the operation, that is fields
+// for all parameters except path parameters, along with types of inlined object parameters.
+func (b *Builder) paramFields(opSpec *openapi3.Operation) ([]StructField, []Writable) {
+	operationName := strcase.ToCamel(opSpec.OperationID)
+	fields := make([]StructField, 0)
+	types := make([]Writable, 0)
+
+	for _, p := range opSpec.Parameters {
+		// path parameters are passed as a parameters to the generated method
+		if p.Value.In == "path" {
+			continue
+		}
+
+		name := p.Value.Name
+		if p.Ref != "" {
+			name = strcase.ToCamel(strings.TrimPrefix(p.Ref, "#/components/schemas/"))
+		}
+
+		typeName := operationName + strcase.ToCamel(p.Value.Name)
+		typ := b.convertToValidGoType(typeName, p.Value.Schema)
+		if typ == typeName {
+			// inlined object parameters need a type of their own
+			_, additionalTypes := b.genSchema(p.Value.Schema, typeName)
+			types = append(types, additionalTypes...)
+		}
+
+		isShared := slices.Contains(b.schemasByTag["shared"], p.Value.Schema.Ref)
+		if isShared && !strings.HasPrefix(typ, "shared.") {
+			typ = "shared." + typ
+		}
+
+		// object parameters are serialized property by property
+		var properties []StructField
+		if schema := p.Value.Schema.Value; isObjectSchema(schema) {
+			properties, _ = b.createFields(allOfProperties(schema), typeName, allOfRequired(schema))
+		}
+
+		optional := !p.Value.Required
+		comment := parameterPropertyGodoc(p.Value)
+		if isIdempotencyKeyParam(p.Value) {
+			// the client generates the key if it's not set
+			optional = true
+			comment = formatGodoc(strings.TrimSpace(p.Value.Description + "\nIf not set, a random key is generated by the client."))
+		}
+
+		pointer := shouldUsePointer(optional, p.Value.Schema, typ)
+		fields = append(fields, StructField{
+			Name:       name,
+			Type:       typ,
+			Parameter:  p.Value,
+			Properties: properties,
+			Optional:   optional,
+			Pointer:    pointer,
+			Comment:    comment,
+		})
+	}
+
+	return fields, types
+}
+
 // pathsToResponseTypes generates response types for operations. This is responsible only for inlined
 // schemas that are specific to the operation itself and are not references.
 func (b *Builder) pathsToResponseTypes(paths *openapi3.Paths) []Writable {
@@ -215,8 +282,11 @@ func (b *Builder) pathsToResponseTypes(paths *openapi3.Paths) []Writable {
 					response = b.spec.Components.Responses[ref]
 				}
 
-				content, ok := response.Value.Content["application/json"]
-				if !ok {
+				_, content := decodedMediaType(response.Value.Content)
+				if content == nil {
+					if isSuccess {
+						paramTypes = append(paramTypes, b.streamItemTypes(operationName, response.Value.Content)...)
+					}
 					continue
 				}
 
@@ -238,9 +308,8 @@ func (b *Builder) pathsToResponseTypes(paths *openapi3.Paths) []Writable {
 				objects := b.generateSchemaComponents(name, content.Schema, isErr)
 				paramTypes = append(paramTypes, objects...)
 
-				if strings.HasPrefix(code, "2") {
-					resp, _ := objects[0].(*TypeDeclaration)
-					successResponses = append(successResponses, resp.Name)
+				if isSuccess {
+					successResponses = append(successResponses, name)
 				}
 			}
 
@@ -250,9 +319,17 @@ func (b *Builder) pathsToResponseTypes(paths *openapi3.Paths) []Writable {
 					slog.Any("responses", successResponses),
 				)
 
+				variants := make([]OneOfVariant, 0, len(successResponses))
+				for _, response := range successResponses {
+					variants = append(variants, OneOfVariant{
+						Name: response,
+						Type: response,
+					})
+				}
+
 				paramTypes = append(paramTypes, &OneOfDeclaration{
-					Name:    operationName + "Response",
-					Options: successResponses,
+					Name:     operationName + "Response",
+					Variants: variants,
 				})
 			}
 		}
@@ -266,7 +343,7 @@ func (b *Builder) pathsToResponseTypes(paths *openapi3.Paths) []Writable {
 // in `#/components/schemas/` part of the OpenAPI specs.
 func (b *Builder) generateSchemaComponents(name string, schema *openapi3.SchemaRef, isErr bool) []Writable {
 	types := make([]Writable, 0)
-	spec := schema.Value
+	spec := withoutNullType(schema.Value)
 
 	switch {
 	case len(spec.Enum) > 0:
@@ -311,38 +388,77 @@ func (b *Builder) generateSchemaComponents(name string, schema *openapi3.SchemaR
 			Name:    name,
 			Schema:  spec,
 		})
-	case spec.Type.Is("object"):
-		object, additionalTypes := b.createObject(spec, name)
+	// `type: object` is commonly used alongside `oneOf`, the variants take precedence
+	// unless the object defines properties of its own.
+	case spec.OneOf != nil && len(spec.Properties) == 0:
+		object, additionalTypes := b.createOneOf(spec, name)
 		types = append(types, object)
 		types = append(types, additionalTypes...)
+		if isErr {
+			types = append(types, unionErrorImplementation{
+				Name:     object.Name,
+				Variants: object.Variants,
+			})
+		}
+	case spec.AnyOf != nil && len(spec.Properties) == 0:
+		// `anyOf: [X, null]` is only a way of saying that X is nullable, in which
+		// case we generate an alias and the nullability is handled by the fields
+		// that reference the type.
+		if variant := nullableAnyOfVariant(spec); variant != nil {
+			typeName, variantTypes := b.genSchema(variant, name+"Value")
+			if slices.Contains(b.schemasByTag["shared"], variant.Ref) {
+				typeName = "shared." + typeName
+			}
+			types = append(types, variantTypes...)
+			types = append(types, &TypeDeclaration{
+				Comment: schemaGodoc(name, spec),
+				Type:    "= " + typeName,
+				Name:    name,
+				Schema:  spec,
+			})
+			break
+		}
 
+		object, additionalTypes := b.createAnyOf(spec, name)
+		types = append(types, object)
+		types = append(types, additionalTypes...)
 		if isErr {
-			types = append(types, errorImplementation{
-				Typ: object,
+			types = append(types, unionErrorImplementation{
+				Name:     object.Name,
+				Variants: object.Variants,
 			})
 		}
-	case spec.OneOf != nil:
-		object := createOneOf(spec, name)
+	case spec.AllOf != nil:
+		// `allOf` with a single non-object member only refines the member, e.g. by making
+		// it nullable or changing its description.
+		if member := singleAllOfMember(spec); member != nil && !isObjectSchema(member.Value) {
+			typeName, memberTypes := b.genSchema(member, name+"Value")
+			if slices.Contains(b.schemasByTag["shared"], member.Ref) {
+				typeName = "shared." + typeName
+			}
+			types = append(types, memberTypes...)
+			types = append(types, &TypeDeclaration{
+				Comment: schemaGodoc(name, spec),
+				Type:    "= " + typeName,
+				Name:    name,
+				Schema:  spec,
+			})
+			break
+		}
+
+		object, additionalTypes := b.createAllOf(spec, name)
 		types = append(types, object)
+		types = append(types, additionalTypes...)
 		if isErr {
 			types = append(types, errorImplementation{
 				Typ: object,
 			})
 		}
-	case spec.AnyOf != nil:
-		slog.Warn("AnyOf not supported, falling back to 'inteface{}'",
-			slog.Any("name", name),
-		)
-		types = append(types, &TypeDeclaration{
-			Comment: schemaGodoc(name, spec),
-			Type:    "interface{}",
-			Name:    name,
-			Schema:  spec,
-		})
-	case spec.AllOf != nil:
-		object, additionalTypes := b.createAllOf(spec, name)
+	case spec.Type.Is("object"):
+		object, additionalTypes := b.createObject(spec, name)
 		types = append(types, object)
 		types = append(types, additionalTypes...)
+
 		if isErr {
 			types = append(types, errorImplementation{
 				Typ: object,
@@ -379,7 +495,7 @@ func (b *Builder) genSchema(schema *openapi3.SchemaRef, name string) (string, []
 	}
 
 	types := make([]Writable, 0)
-	spec := schema.Value
+	spec := withoutNullType(schema.Value)
 
 	switch {
 	case len(spec.Enum) > 0:
@@ -389,36 +505,49 @@ func (b *Builder) genSchema(schema *openapi3.SchemaRef, name string) (string, []
 		}
 		return stringx.MakeSingular(name), types
 	case spec.Type.Is("string"):
-		return formatStringType(schema.Value), nil
+		return formatStringType(spec), nil
 	case spec.Type.Is("integer"):
-		return formatIntegerType(schema.Value), nil
+		return formatIntegerType(spec), nil
 	case spec.Type.Is("number"):
-		return formatNumberType(schema.Value), nil
+		return formatNumberType(spec), nil
 	case spec.Type.Is("boolean"):
 		return "bool", nil
 	case spec.Type.Is("array"):
 		typeName, schemas := b.genSchema(spec.Items, stringx.MakeSingular(name))
 		types = append(types, schemas...)
 		return "[]" + typeName, types
-	case spec.Type.Is("object"):
-		object, additionalTypes := b.createObject(spec, name)
+	case spec.OneOf != nil && len(spec.Properties) == 0:
+		object, additionalTypes := b.createOneOf(spec, name)
 		types = append(types, object)
 		types = append(types, additionalTypes...)
 		return name, types
-	case spec.OneOf != nil:
-		object := createOneOf(spec, name)
+	case spec.AnyOf != nil && len(spec.Properties) == 0:
+		if variant := nullableAnyOfVariant(spec); variant != nil {
+			return b.genSchema(variant, name)
+		}
+
+		object, additionalTypes := b.createAnyOf(spec, name)
 		types = append(types, object)
+		types = append(types, additionalTypes...)
 		return name, types
-	case spec.AnyOf != nil:
-		slog.Warn("AnyOf not supported, falling back to 'any'",
-			slog.Any("name", name),
-		)
-		return "interface{}", nil
 	case spec.AllOf != nil:
+		if member := singleAllOfMember(spec); member != nil && !isObjectSchema(member.Value) {
+			typeName, memberTypes := b.genSchema(member, name)
+			if slices.Contains(b.schemasByTag["shared"], member.Ref) {
+				typeName = "shared." + typeName
+			}
+			return typeName, memberTypes
+		}
+
 		object, additionalTypes := b.createAllOf(spec, name)
 		types = append(types, object)
 		types = append(types, additionalTypes...)
 		return name, types
+	case spec.Type.Is("object"):
+		object, additionalTypes := b.createObject(spec, name)
+		types = append(types, object)
+		types = append(types, additionalTypes...)
+		return name, types
 	default:
 		if spec.Type != nil {
 			slog.Warn("skipping unknown type",
@@ -503,21 +632,33 @@ func (b *Builder) createFields(properties map[string]*openapi3.SchemaRef, name s
 			typeName = "shared." + typeName
 		}
 
+		optional := !slices.Contains(required, property)
+		nullable := isNullable(schema.Value)
+
+		// Optional nullable fields need to distinguish between value that is not set and
+		// value that is explicitly set to null. Required nullable fields only need a pointer.
 		tags := []string{strcase.ToSnake(property)}
-		if !slices.Contains(required, property) {
+		switch {
+		case optional && nullable:
+			tags = append(tags, "omitzero")
+		case optional:
 			tags = append(tags, "omitempty")
 		}
-		optional := !slices.Contains(required, property)
-		pointer := shouldUsePointer(optional, schema, typeName)
+		fieldTags := map[string][]string{
+			"json": tags,
+		}
+		if b.xmlTags {
+			fieldTags["xml"] = xmlTag(property, schema, optional)
+		}
 		fields = append(fields, StructField{
-			Name:    property,
-			Type:    typeName,
-			Comment: schemaPropertyGodoc(schema.Value),
-			Tags: map[string][]string{
-				"json": tags,
-			},
+			Name:     property,
+			Type:     typeName,
+			Comment:  schemaPropertyGodoc(schema.Value),
+			Tags:     fieldTags,
 			Optional: optional,
-			Pointer:  pointer,
+			Pointer:  !(optional && nullable) && shouldUsePointer(optional || nullable, schema, typeName),
+			Nullable: optional && nullable,
+			Schema:   schema,
 		})
 		types = append(types, moreTypes...)
 	}
@@ -707,67 +848,483 @@ func createEnum(schema *openapi3.Schema, name string) Writable {
 	}
 }
 
-// createAllOf creates a type declaration for `allOf` schema.
+// isNullable reports whether the schema permits `null` in addition to other values, either
+// through `nullable: true` (OpenAPI 3.0), `type: [X, "null"]` (OpenAPI 3.1), or `anyOf: [X, null]`.
+func isNullable(schema *openapi3.Schema) bool {
+	if schema == nil {
+		return false
+	}
+
+	return schema.Nullable ||
+		(schema.Type.Includes("null") && len(schema.Type.Slice()) > 1) ||
+		nullableAnyOfVariant(schema) != nil
+}
+
+// withoutNullType returns the schema with the `null` type removed from the list of types,
+// so that `type: [string, "null"]` can be handled the same way as `type: string`.
+func withoutNullType(schema *openapi3.Schema) *openapi3.Schema {
+	if schema == nil || !schema.Type.Includes("null") || len(schema.Type.Slice()) < 2 {
+		return schema
+	}
+
+	types := slices.DeleteFunc(slices.Clone(schema.Type.Slice()), func(t string) bool { return t == "null" })
+	withoutNull := *schema
+	withoutNull.Type = (*openapi3.Types)(&types)
+	return &withoutNull
+}
+
+// createAllOf creates a type declaration for `allOf` schema. Members that reference
+// object schemas are embedded, properties of inlined members are merged into the type.
+// Conflicting properties are reported by [Builder.validateAllOf] when loading the specs.
 func (b *Builder) createAllOf(schema *openapi3.Schema, name string) (*TypeDeclaration, []Writable) {
-	types := []Writable{}
 	var fields []StructField
-	var seen []string
+	properties := make(openapi3.Schemas)
+	required := slices.Clone(schema.Required)
+	embeddedProperties := make(map[string]struct{})
 	for _, s := range schema.AllOf {
-		// Solve collision between the properties of `allOf` before we pass it further to avoid
-		// generating nested objects and enums multiple times.
-		properties := s.Value.Properties
-		for _, f := range seen {
-			delete(properties, f)
+		if s.Ref != "" && isObjectSchema(s.Value) {
+			typeName := b.getReferenceSchema(s)
+			fields = append(fields, StructField{
+				Name:     strings.TrimPrefix(typeName, "shared."),
+				Type:     typeName,
+				Embedded: true,
+			})
+			for property := range allOfProperties(s.Value) {
+				embeddedProperties[property] = struct{}{}
+			}
+			continue
 		}
 
-		objectFields, additionalTypes := b.createFields(properties, name, s.Value.Required)
-		fields = append(fields, objectFields...)
-		types = append(types, additionalTypes...)
+		maps.Copy(properties, allOfProperties(s.Value))
+		required = append(required, allOfRequired(s.Value)...)
+	}
+	maps.Copy(properties, schema.Properties)
 
-		seen = append(seen, slices.Collect(maps.Keys(properties))...)
+	// Properties provided by the embedded structs would only shadow the embedded fields.
+	for property := range embeddedProperties {
+		delete(properties, property)
 	}
 
+	objectFields, types := b.createFields(properties, name, required)
+	fields = append(fields, objectFields...)
+
 	return &TypeDeclaration{
 		Comment: schemaGodoc(name, schema),
 		Name:    name,
 		Type:    "struct",
-		Fields:  uniqueFields(fields),
+		Fields:  fields,
 		Schema:  schema,
 	}, types
 }
 
-// createOneOf creates a type declaration for `oneOf` schema.
-func createOneOf(schema *openapi3.Schema, name string) *TypeDeclaration {
-	// TODO: implement `func (v *{{name}}) AsXXX() (XXX, error) { ... }`
-	// that allows converting one of from `json.RawMessage` to possible variants.
+// allOfProperties returns properties of the schema including the properties of its
+// `allOf` members.
+func allOfProperties(schema *openapi3.Schema) openapi3.Schemas {
+	properties := make(openapi3.Schemas)
+	if schema == nil {
+		return properties
+	}
 
-	return &TypeDeclaration{
-		Comment: schemaGodoc(name, schema),
-		Name:    name,
-		Type:    "json.RawMessage",
-		Schema:  schema,
+	for _, s := range schema.AllOf {
+		maps.Copy(properties, allOfProperties(s.Value))
+	}
+	maps.Copy(properties, schema.Properties)
+
+	return properties
+}
+
+// allOfRequired returns required properties of the schema including the required
+// properties of its `allOf` members.
+func allOfRequired(schema *openapi3.Schema) []string {
+	if schema == nil {
+		return nil
+	}
+
+	required := slices.Clone(schema.Required)
+	for _, s := range schema.AllOf {
+		required = append(required, allOfRequired(s.Value)...)
+	}
+
+	return required
+}
+
+// singleAllOfMember returns the only member of `allOf` that carries type information,
+// ignoring members that only annotate the schema (e.g. with description). Returns nil
+// if there are multiple such members or the schema defines properties of its own.
+func singleAllOfMember(schema *openapi3.Schema) *openapi3.SchemaRef {
+	if schema == nil || len(schema.Properties) != 0 {
+		return nil
 	}
+
+	var member *openapi3.SchemaRef
+	for _, s := range schema.AllOf {
+		if s.Ref == "" && isAnnotationSchema(s.Value) {
+			continue
+		}
+		if member != nil {
+			return nil
+		}
+		member = s
+	}
+
+	return member
 }
 
-func uniqueFields(fields []StructField) []StructField {
-	return uniqueFunc(fields, func(f StructField) string { return f.Name })
+// isAnnotationSchema reports whether the schema carries no type information and only
+// annotates other schemas, e.g. `{description: "..."}`.
+func isAnnotationSchema(schema *openapi3.Schema) bool {
+	return schema == nil || (schema.Type == nil &&
+		len(schema.Properties) == 0 &&
+		len(schema.Enum) == 0 &&
+		schema.Items == nil &&
+		schema.AllOf == nil &&
+		schema.OneOf == nil &&
+		schema.AnyOf == nil)
 }
 
-func uniqueFunc[T any, C comparable](arr []T, keyFn func(T) C) []T {
-	seen := make(map[C]bool)
+// isObjectSchema reports whether the schema is generated as a struct, that is whether
+// it can be embedded.
+func isObjectSchema(schema *openapi3.Schema) bool {
+	switch {
+	case schema == nil || len(schema.Enum) > 0:
+		return false
+	case (schema.OneOf != nil || schema.AnyOf != nil) && len(schema.Properties) == 0:
+		return false
+	case schema.AllOf != nil:
+		member := singleAllOfMember(schema)
+		return member == nil || isObjectSchema(member.Value)
+	case schema.Type.Is("object"):
+		return !isAdditionalPropertiesMap(schema)
+	default:
+		return false
+	}
+}
 
-	n := 0
-	for _, ele := range arr {
-		key := keyFn(ele)
-		if ok := seen[key]; ok {
+// validateAllOf checks that the members of `allOf` schemas don't define the same property
+// with different schemas as such schemas can't be represented by Go structs.
+func (b *Builder) validateAllOf() error {
+	visited := make(map[*openapi3.Schema]struct{})
+
+	if b.spec.Components != nil {
+		names := slices.Collect(maps.Keys(b.spec.Components.Schemas))
+		slices.Sort(names)
+		for _, name := range names {
+			if err := allOfConflicts(b.spec.Components.Schemas[name], "#/components/schemas/"+name, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, path := range b.spec.Paths.InMatchingOrder() {
+		operations := b.spec.Paths.Find(path).Operations()
+		methods := slices.Collect(maps.Keys(operations))
+		slices.Sort(methods)
+		for _, method := range methods {
+			op := operations[method]
+			base := "#/paths/" + escape.Replace(path) + "/" + strings.ToLower(method)
+
+			for i, p := range op.Parameters {
+				if p.Value == nil {
+					continue
+				}
+				if err := allOfConflicts(p.Value.Schema, fmt.Sprintf("%s/parameters/%d/schema", base, i), visited); err != nil {
+					return err
+				}
+			}
+
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				for mt, content := range op.RequestBody.Value.Content {
+					if err := allOfConflicts(content.Schema, base+"/requestBody/content/"+escape.Replace(mt)+"/schema", visited); err != nil {
+						return err
+					}
+				}
+			}
+
+			if op.Responses == nil {
+				continue
+			}
+			for code, response := range op.Responses.Map() {
+				if response.Value == nil {
+					continue
+				}
+				for mt, content := range response.Value.Content {
+					if err := allOfConflicts(content.Schema, base+"/responses/"+code+"/content/"+escape.Replace(mt)+"/schema", visited); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// allOfConflicts recursively looks for conflicting properties of `allOf` members in
+// the schema. Referenced schemas are not followed as they are validated on their own.
+func allOfConflicts(schema *openapi3.SchemaRef, path string, visited map[*openapi3.Schema]struct{}) error {
+	if schema == nil || schema.Value == nil {
+		return nil
+	}
+	if _, ok := visited[schema.Value]; ok {
+		return nil
+	}
+	visited[schema.Value] = struct{}{}
+
+	spec := schema.Value
+	if len(spec.AllOf) > 0 {
+		type definition struct {
+			schema *openapi3.SchemaRef
+			member string
+		}
+
+		members := make([]*openapi3.Schema, 0, len(spec.AllOf)+1)
+		for _, s := range spec.AllOf {
+			members = append(members, s.Value)
+		}
+		members = append(members, &openapi3.Schema{Properties: spec.Properties})
+
+		seen := make(map[string]definition)
+		// embeddedBy maps properties of the referenced members, that are embedded in the
+		// generated struct, to the member defining them. A property promoted from multiple
+		// embedded structs would be ambiguous and ignored by encoding/json.
+		embeddedBy := make(map[string]string)
+		for i, member := range members {
+			memberPath := fmt.Sprintf("%s/allOf/%d", path, i)
+			if i == len(spec.AllOf) {
+				memberPath = path
+			}
+			embedded := i < len(spec.AllOf) && spec.AllOf[i].Ref != "" && isObjectSchema(member)
+
+			properties := allOfProperties(member)
+			keys := slices.Collect(maps.Keys(properties))
+			slices.Sort(keys)
+			for _, property := range keys {
+				if prev, ok := embeddedBy[property]; ok && embedded {
+					return fmt.Errorf("%s/properties/%s: property %q is also defined in %s, properties of referenced allOf members must be unique",
+						memberPath, property, property, prev)
+				}
+				if _, ok := embeddedBy[property]; !ok && embedded {
+					embeddedBy[property] = memberPath
+				}
+
+				prev, ok := seen[property]
+				if ok && !compatibleSchemas(prev.schema, properties[property]) {
+					return fmt.Errorf("%s/properties/%s: property %q conflicts with the property defined in %s",
+						memberPath, property, property, prev.member)
+				}
+				if !ok {
+					seen[property] = definition{schema: properties[property], member: memberPath}
+				}
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(spec.Properties)) {
+		if err := allOfConflicts(refless(spec.Properties[name]), path+"/properties/"+name, visited); err != nil {
+			return err
+		}
+	}
+	if err := allOfConflicts(refless(spec.Items), path+"/items", visited); err != nil {
+		return err
+	}
+	if err := allOfConflicts(refless(spec.AdditionalProperties.Schema), path+"/additionalProperties", visited); err != nil {
+		return err
+	}
+	for kind, members := range map[string]openapi3.SchemaRefs{"allOf": spec.AllOf, "oneOf": spec.OneOf, "anyOf": spec.AnyOf} {
+		for i, member := range members {
+			if err := allOfConflicts(refless(member), fmt.Sprintf("%s/%s/%d", path, kind, i), visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// refless returns nil for schema references, otherwise returns the schema.
+func refless(schema *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if schema == nil || schema.Ref != "" {
+		return nil
+	}
+	return schema
+}
+
+// compatibleSchemas reports whether the two definitions of a property can be represented
+// by a single struct field.
+func compatibleSchemas(a, b *openapi3.SchemaRef) bool {
+	switch {
+	case a == b:
+		return true
+	case a.Ref != "" || b.Ref != "":
+		return a.Ref == b.Ref ||
+			(a.Ref == "" && isAnnotationSchema(a.Value)) ||
+			(b.Ref == "" && isAnnotationSchema(b.Value))
+	case a.Value == nil || b.Value == nil:
+		return true
+	case isAnnotationSchema(a.Value) || isAnnotationSchema(b.Value):
+		return true
+	default:
+		return slices.Equal(a.Value.Type.Slice(), b.Value.Type.Slice()) &&
+			a.Value.Format == b.Value.Format &&
+			len(a.Value.Properties) == 0 && len(b.Value.Properties) == 0 &&
+			a.Value.Items == nil && b.Value.Items == nil
+	}
+}
+
+// createOneOf creates a sum type declaration for `oneOf` schema.
+func (b *Builder) createOneOf(schema *openapi3.Schema, name string) (*OneOfDeclaration, []Writable) {
+	variants, types := b.createVariants(schema.OneOf, name, schema.Discriminator)
+
+	var discriminator string
+	if schema.Discriminator != nil {
+		discriminator = schema.Discriminator.PropertyName
+	}
+
+	return &OneOfDeclaration{
+		Comment:       schemaGodoc(name, schema),
+		Name:          name,
+		Variants:      variants,
+		Discriminator: discriminator,
+		Schema:        schema,
+	}, types
+}
+
+// createAnyOf creates a type declaration for `anyOf` schema.
+func (b *Builder) createAnyOf(schema *openapi3.Schema, name string) (*AnyOfDeclaration, []Writable) {
+	variants, types := b.createVariants(schema.AnyOf, name, nil)
+
+	return &AnyOfDeclaration{
+		Comment:  schemaGodoc(name, schema),
+		Name:     name,
+		Variants: variants,
+		Schema:   schema,
+	}, types
+}
+
+// createVariants converts `oneOf` or `anyOf` members into variants, generating types for
+// the inlined members. Members of type `null` are skipped as the nullability is expressed
+// by the union itself.
+func (b *Builder) createVariants(
+	schemas openapi3.SchemaRefs,
+	name string,
+	discriminator *openapi3.Discriminator,
+) ([]OneOfVariant, []Writable) {
+	types := []Writable{}
+	variants := make([]OneOfVariant, 0, len(schemas))
+	for i, s := range schemas {
+		if isNullSchema(s.Value) {
 			continue
 		}
-		arr[n] = ele
-		n++
-		seen[key] = true
+
+		variantName := fmt.Sprintf("%sVariant%d", name, i+1)
+		if s.Ref == "" && s.Value.Title != "" {
+			variantName = name + strcase.ToCamel(s.Value.Title)
+		}
+
+		typeName, moreTypes := b.genSchema(s, variantName)
+		if s.Ref != "" && slices.Contains(b.schemasByTag["shared"], s.Ref) {
+			typeName = "shared." + typeName
+		}
+		types = append(types, moreTypes...)
+
+		variants = append(variants, OneOfVariant{
+			Name:                oneOfVariantName(typeName, variants),
+			Type:                typeName,
+			DiscriminatorValues: discriminatorValues(discriminator, s),
+			Required:            variantRequired(s.Value),
+		})
+	}
+
+	return variants, types
+}
+
+// variantRequired returns the sorted required properties of a variant schema.
+func variantRequired(schema *openapi3.Schema) []string {
+	required := allOfRequired(schema)
+	slices.Sort(required)
+	return slices.Compact(required)
+}
+
+// isNullSchema reports whether the schema only permits `null` value.
+func isNullSchema(schema *openapi3.Schema) bool {
+	return schema != nil && schema.Type.Is("null")
+}
+
+// nullableAnyOfVariant returns the only variant of `anyOf: [X, null]` schema. Returns nil if
+// the schema is not of this form.
+func nullableAnyOfVariant(schema *openapi3.Schema) *openapi3.SchemaRef {
+	if schema == nil || len(schema.AnyOf) != 2 {
+		return nil
+	}
+
+	for i, s := range schema.AnyOf {
+		if isNullSchema(s.Value) {
+			return schema.AnyOf[1-i]
+		}
+	}
+
+	return nil
+}
+
+// reservedVariantNames are names of the methods of the generated unions that can't be
+// used as names of the variant fields.
+var reservedVariantNames = []string{"Error", "Raw"}
+
+// oneOfVariantName returns name of the field and accessor for a variant of given type,
+// e.g. `shared.Card` becomes `Card` and `[]string` becomes `StringList`.
+func oneOfVariantName(typeName string, variants []OneOfVariant) string {
+	name := strings.TrimPrefix(typeName, "[]")
+	if i := strings.LastIndex(name, "."); i != -1 {
+		name = name[i+1:]
+	}
+	if strings.HasPrefix(name, "map[") {
+		name = "Map"
+	}
+	name = strcase.ToCamel(name)
+	if strings.HasPrefix(typeName, "[]") {
+		name += "List"
+	}
+
+	taken := func(name string) bool {
+		return slices.Contains(reservedVariantNames, name) ||
+			slices.ContainsFunc(variants, func(v OneOfVariant) bool { return v.Name == name })
+	}
+	if slices.Contains(reservedVariantNames, name) {
+		name += "Variant"
+	}
+	if !taken(name) {
+		return name
+	}
+
+	for i := len(variants) + 1; ; i++ {
+		if candidate := fmt.Sprintf("%s%d", name, i); !taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+// discriminatorValues returns values of the discriminator property that select the variant.
+// If the discriminator doesn't define an explicit mapping for the variant, the name of the
+// referenced schema is used as per the OpenAPI specification.
+func discriminatorValues(discriminator *openapi3.Discriminator, variant *openapi3.SchemaRef) []string {
+	if discriminator == nil || variant.Ref == "" {
+		return nil
+	}
+
+	values := make([]string, 0)
+	for value, ref := range discriminator.Mapping {
+		if ref == variant.Ref || "#/components/schemas/"+ref == variant.Ref {
+			values = append(values, value)
+		}
+	}
+	slices.Sort(values)
+
+	if len(values) == 0 {
+		values = append(values, strings.TrimPrefix(variant.Ref, "#/components/schemas/"))
 	}
 
-	return arr[:n]
+	return values
 }
 
 func (b *Builder) getResponseName(operationName, responseCode string, content *openapi3.MediaType) string {
@@ -0,0 +1,163 @@
+package builder
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestDiscriminatorValues(t *testing.T) {
+	discriminator := &openapi3.Discriminator{
+		PropertyName: "type",
+		Mapping: openapi3.StringMap{
+			"card":   "#/components/schemas/Card",
+			"credit": "#/components/schemas/Card",
+			"bank":   "#/components/schemas/BankTransfer",
+		},
+	}
+
+	got := discriminatorValues(discriminator, &openapi3.SchemaRef{Ref: "#/components/schemas/Card"})
+	if !slices.Equal(got, []string{"card", "credit"}) {
+		t.Fatalf("expected explicit mapping values, got %v", got)
+	}
+
+	got = discriminatorValues(discriminator, &openapi3.SchemaRef{Ref: "#/components/schemas/Wallet"})
+	if !slices.Equal(got, []string{"Wallet"}) {
+		t.Fatalf("expected implicit mapping to schema name, got %v", got)
+	}
+
+	got = discriminatorValues(nil, &openapi3.SchemaRef{Ref: "#/components/schemas/Card"})
+	if got != nil {
+		t.Fatalf("expected no values without discriminator, got %v", got)
+	}
+}
+
+func TestOneOfVariantName(t *testing.T) {
+	for typ, want := range map[string]string{
+		"shared.Card":    "Card",
+		"string":         "String",
+		"[]string":       "StringList",
+		"datetime.Date":  "Date",
+		"map[string]any": "Map",
+		"shared.Error":   "ErrorVariant",
+	} {
+		if got := oneOfVariantName(typ, nil); got != want {
+			t.Errorf("oneOfVariantName(%q) = %q, want %q", typ, got, want)
+		}
+	}
+	// `String3` is taken by the variant of type `string3`
+	variants := []OneOfVariant{{Name: "String"}, {Name: "String3"}}
+	if got, want := oneOfVariantName("string", variants), "String4"; got != want {
+		t.Errorf("expected unique variant name, got %q, want %q", got, want)
+	}
+}
+
+func TestNullableAnyOfVariant(t *testing.T) {
+	card := &openapi3.SchemaRef{Ref: "#/components/schemas/Card", Value: &openapi3.Schema{}}
+	null := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"null"}}}
+
+	if got := nullableAnyOfVariant(&openapi3.Schema{AnyOf: openapi3.SchemaRefs{null, card}}); got != card {
+		t.Fatalf("expected the non-null variant, got %v", got)
+	}
+
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	if got := nullableAnyOfVariant(&openapi3.Schema{AnyOf: openapi3.SchemaRefs{card, str}}); got != nil {
+		t.Fatalf("expected no variant for anyOf without null, got %v", got)
+	}
+}
+
+func TestSharedAlias(t *testing.T) {
+	const ref = "#/components/schemas/Currency"
+	b := &Builder{schemasByTag: map[string][]string{"shared": {ref}}}
+	currency := &openapi3.SchemaRef{Ref: ref, Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	null := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"null"}}}
+
+	for name, schema := range map[string]*openapi3.Schema{
+		"anyOf": {AnyOf: openapi3.SchemaRefs{currency, null}},
+		"allOf": {AllOf: openapi3.SchemaRefs{currency}, Description: "Price currency."},
+	} {
+		types := b.generateSchemaComponents("PriceCurrency", &openapi3.SchemaRef{Value: schema}, false)
+		alias, ok := types[len(types)-1].(*TypeDeclaration)
+		if !ok {
+			t.Fatalf("%s: expected type declaration, got %T", name, types[len(types)-1])
+		}
+		if want := "= shared.Currency"; alias.Type != want {
+			t.Errorf("%s: got alias %q, want %q", name, alias.Type, want)
+		}
+	}
+}
+
+func TestAllOfConflicts(t *testing.T) {
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	integer := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}}
+	description := &openapi3.SchemaRef{Value: &openapi3.Schema{Description: "Identifier."}}
+
+	compatible := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		AllOf: openapi3.SchemaRefs{
+			{Value: &openapi3.Schema{Properties: openapi3.Schemas{"id": str}}},
+			{Value: &openapi3.Schema{Properties: openapi3.Schemas{"id": description}}},
+		},
+	}}
+	if err := allOfConflicts(compatible, "#/components/schemas/Card", make(map[*openapi3.Schema]struct{})); err != nil {
+		t.Fatalf("expected no conflict for annotated property, got %v", err)
+	}
+
+	conflicting := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		AllOf: openapi3.SchemaRefs{
+			{Value: &openapi3.Schema{Properties: openapi3.Schemas{"id": str}}},
+			{Value: &openapi3.Schema{Properties: openapi3.Schemas{"id": integer}}},
+		},
+	}}
+	err := allOfConflicts(conflicting, "#/components/schemas/Card", make(map[*openapi3.Schema]struct{}))
+	if err == nil {
+		t.Fatal("expected conflict error")
+	}
+	if want := `#/components/schemas/Card/allOf/1/properties/id: property "id" conflicts with the property defined in #/components/schemas/Card/allOf/0`; err.Error() != want {
+		t.Fatalf("unexpected error:\n got: %s\nwant: %s", err, want)
+	}
+
+	object := &openapi3.Types{"object"}
+	embedded := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		AllOf: openapi3.SchemaRefs{
+			{Ref: "#/components/schemas/Card", Value: &openapi3.Schema{Type: object, Properties: openapi3.Schemas{"type": str}}},
+			{Ref: "#/components/schemas/BankTransfer", Value: &openapi3.Schema{Type: object, Properties: openapi3.Schemas{"type": str}}},
+		},
+	}}
+	err = allOfConflicts(embedded, "#/components/schemas/Instrument", make(map[*openapi3.Schema]struct{}))
+	if err == nil {
+		t.Fatal("expected conflict error for property of multiple referenced members")
+	}
+	if want := `#/components/schemas/Instrument/allOf/1/properties/type: property "type" is also defined in #/components/schemas/Instrument/allOf/0, properties of referenced allOf members must be unique`; err.Error() != want {
+		t.Fatalf("unexpected error:\n got: %s\nwant: %s", err, want)
+	}
+}
+
+func TestIsNullable(t *testing.T) {
+	for name, tc := range map[string]struct {
+		schema *openapi3.Schema
+		want   bool
+	}{
+		"nullable": {
+			schema: &openapi3.Schema{Type: &openapi3.Types{"string"}, Nullable: true},
+			want:   true,
+		},
+		"type with null": {
+			schema: &openapi3.Schema{Type: &openapi3.Types{"string", "null"}},
+			want:   true,
+		},
+		"plain": {
+			schema: &openapi3.Schema{Type: &openapi3.Types{"string"}},
+			want:   false,
+		},
+	} {
+		if got := isNullable(tc.schema); got != tc.want {
+			t.Errorf("%s: isNullable() = %v, want %v", name, got, tc.want)
+		}
+	}
+
+	withoutNull := withoutNullType(&openapi3.Schema{Type: &openapi3.Types{"integer", "null"}})
+	if !withoutNull.Type.Is("integer") {
+		t.Fatalf("expected null to be removed from types, got %v", withoutNull.Type.Slice())
+	}
+}
@@ -3,6 +3,7 @@ package builder
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"slices"
 	"strings"
 
@@ -22,6 +23,13 @@ func (tt *TypeDeclaration) String() string {
 	fmt.Fprintf(buf, "type %s %s", tt.Name, tt.Type)
 	if tt.Fields != nil {
 		slices.SortFunc(tt.Fields, func(a, b StructField) int {
+			// embedded structs go first
+			if a.Embedded != b.Embedded {
+				if a.Embedded {
+					return -1
+				}
+				return 1
+			}
 			return strings.Compare(a.Name, b.Name)
 		})
 		fmt.Fprint(buf, " {\n")
@@ -37,30 +45,215 @@ func (tt *TypeDeclaration) String() string {
 
 func (o *OneOfDeclaration) String() string {
 	buf := new(strings.Builder)
+	if o.Comment != "" {
+		fmt.Fprintf(buf, "// %s\n", o.Comment)
+	}
 	fmt.Fprintf(buf, "type %s struct {\n", o.Name)
-	for _, opt := range o.Options {
-		fmt.Fprintf(buf, "\t%s *%s\n", opt, opt)
-	}
-	fmt.Fprintf(buf, "}\n\n")
-	for _, opt := range o.Options {
-		fmt.Fprintf(buf, "func (r *%s) As%s() (*%s, bool) {\n", o.Name, opt, opt)
-		fmt.Fprintf(buf, "\tif r.%s != nil {\n", opt)
-		fmt.Fprintf(buf, "\t\treturn r.%s, true\n", opt)
+	for _, v := range o.Variants {
+		fmt.Fprintf(buf, "\t%s *%s\n", v.Name, v.Type)
+	}
+	fmt.Fprint(buf, "}\n\n")
+
+	o.writeUnmarshalJSON(buf)
+	o.writeMarshalJSON(buf)
+
+	for _, v := range o.Variants {
+		fmt.Fprintf(buf, "// As%s returns the %s variant of [%s], if set.\n", v.Name, v.Name, o.Name)
+		fmt.Fprintf(buf, "func (v *%s) As%s() (*%s, bool) {\n", o.Name, v.Name, v.Type)
+		fmt.Fprintf(buf, "\treturn v.%s, v.%s != nil\n", v.Name, v.Name)
+		fmt.Fprint(buf, "}\n\n")
+	}
+	return buf.String()
+}
+
+// writeUnmarshalJSON writes [json.Unmarshaler] implementation of the oneOf type. If the type
+// has a discriminator, the variant is picked based on the discriminator value, otherwise
+// the variants are tried in order and the first variant that decodes the data without
+// unknown fields wins.
+func (o *OneOfDeclaration) writeUnmarshalJSON(buf *strings.Builder) {
+	fmt.Fprintf(buf, "// UnmarshalJSON implements [json.Unmarshaler].\n")
+	fmt.Fprintf(buf, "func (v *%s) UnmarshalJSON(data []byte) error {\n", o.Name)
+
+	undiscriminated := o.Variants
+	if o.Discriminator != "" {
+		undiscriminated = make([]OneOfVariant, 0)
+		fmt.Fprint(buf, "\tvar discriminator struct {\n")
+		fmt.Fprintf(buf, "\t\tValue string `json:%q`\n", o.Discriminator)
+		fmt.Fprint(buf, "\t}\n")
+		fmt.Fprint(buf, "\tif err := json.Unmarshal(data, &discriminator); err != nil {\n")
+		fmt.Fprintf(buf, "\t\treturn fmt.Errorf(\"decode %s discriminator: %%w\", err)\n", o.Name)
 		fmt.Fprint(buf, "\t}\n\n")
-		fmt.Fprint(buf, "\t\treturn nil, false\n")
+		fmt.Fprint(buf, "\tswitch discriminator.Value {\n")
+		for _, variant := range o.Variants {
+			if len(variant.DiscriminatorValues) == 0 {
+				undiscriminated = append(undiscriminated, variant)
+				continue
+			}
+			values := make([]string, 0, len(variant.DiscriminatorValues))
+			for _, value := range variant.DiscriminatorValues {
+				values = append(values, fmt.Sprintf("%q", value))
+			}
+			fmt.Fprintf(buf, "\tcase %s:\n", strings.Join(values, ", "))
+			fmt.Fprintf(buf, "\t\tvar variant %s\n", variant.Type)
+			fmt.Fprint(buf, "\t\tif err := json.Unmarshal(data, &variant); err != nil {\n")
+			fmt.Fprintf(buf, "\t\t\treturn fmt.Errorf(\"decode %s as %s: %%w\", err)\n", o.Name, variant.Name)
+			fmt.Fprint(buf, "\t\t}\n")
+			fmt.Fprintf(buf, "\t\t*v = %s{%s: &variant}\n", o.Name, variant.Name)
+			fmt.Fprint(buf, "\t\treturn nil\n")
+		}
 		fmt.Fprint(buf, "\t}\n\n")
 	}
-	return buf.String()
+
+	for _, variant := range undiscriminated {
+		fmt.Fprint(buf, "\t{\n")
+		fmt.Fprintf(buf, "\t\tvar variant %s\n", variant.Type)
+		fmt.Fprint(buf, "\t\tdec := json.NewDecoder(bytes.NewReader(data))\n")
+		fmt.Fprint(buf, "\t\tdec.DisallowUnknownFields()\n")
+		fmt.Fprint(buf, "\t\tif err := dec.Decode(&variant); err == nil {\n")
+		fmt.Fprintf(buf, "\t\t\t*v = %s{%s: &variant}\n", o.Name, variant.Name)
+		fmt.Fprint(buf, "\t\t\treturn nil\n")
+		fmt.Fprint(buf, "\t\t}\n")
+		fmt.Fprint(buf, "\t}\n\n")
+	}
+
+	if o.Discriminator != "" {
+		fmt.Fprintf(buf, "\treturn fmt.Errorf(\"decode %s: unknown %s %%q\", discriminator.Value)\n", o.Name, o.Discriminator)
+	} else {
+		fmt.Fprintf(buf, "\treturn fmt.Errorf(\"decode %s: data does not match any variant\")\n", o.Name)
+	}
+	fmt.Fprint(buf, "}\n\n")
 }
 
-func (f *StructField) String() string {
+// writeMarshalJSON writes [json.Marshaler] implementation of the oneOf type that encodes
+// the variant that is set.
+func (o *OneOfDeclaration) writeMarshalJSON(buf *strings.Builder) {
+	fmt.Fprintf(buf, "// MarshalJSON implements [json.Marshaler].\n")
+	fmt.Fprintf(buf, "func (v %s) MarshalJSON() ([]byte, error) {\n", o.Name)
+	fmt.Fprint(buf, "\tswitch {\n")
+	for _, variant := range o.Variants {
+		fmt.Fprintf(buf, "\tcase v.%s != nil:\n", variant.Name)
+		fmt.Fprintf(buf, "\t\treturn json.Marshal(v.%s)\n", variant.Name)
+	}
+	fmt.Fprint(buf, "\tdefault:\n")
+	fmt.Fprint(buf, "\t\treturn []byte(\"null\"), nil\n")
+	fmt.Fprint(buf, "\t}\n")
+	fmt.Fprint(buf, "}\n\n")
+}
+
+func (a *AnyOfDeclaration) String() string {
 	buf := new(strings.Builder)
-	if f.Comment != "" {
-		fmt.Fprintf(buf, "// %s\n", f.Comment)
+	if a.Comment != "" {
+		fmt.Fprintf(buf, "// %s\n", a.Comment)
 	}
-	name := f.Name
+	fmt.Fprintf(buf, "type %s struct {\n", a.Name)
+	for _, v := range a.Variants {
+		fmt.Fprintf(buf, "\t%s *%s\n", v.Name, v.Type)
+	}
+	fmt.Fprint(buf, "\n\traw json.RawMessage\n")
+	fmt.Fprint(buf, "}\n\n")
+
+	a.writeUnmarshalJSON(buf)
+
+	fmt.Fprint(buf, "// MarshalJSON implements [json.Marshaler]. If multiple variants are set, their\n")
+	fmt.Fprint(buf, "// properties are merged into a single object.\n")
+	fmt.Fprintf(buf, "func (v %s) MarshalJSON() ([]byte, error) {\n", a.Name)
+	fmt.Fprintf(buf, "\tvariants := make([]any, 0, %d)\n", len(a.Variants))
+	for _, variant := range a.Variants {
+		fmt.Fprintf(buf, "\tif v.%s != nil {\n", variant.Name)
+		fmt.Fprintf(buf, "\t\tvariants = append(variants, v.%s)\n", variant.Name)
+		fmt.Fprint(buf, "\t}\n")
+	}
+	fmt.Fprint(buf, "\n\tswitch len(variants) {\n")
+	fmt.Fprint(buf, "\tcase 0:\n")
+	fmt.Fprint(buf, "\t\tif v.raw != nil {\n")
+	fmt.Fprint(buf, "\t\t\treturn v.raw, nil\n")
+	fmt.Fprint(buf, "\t\t}\n")
+	fmt.Fprint(buf, "\t\treturn []byte(\"null\"), nil\n")
+	fmt.Fprint(buf, "\tcase 1:\n")
+	fmt.Fprint(buf, "\t\treturn json.Marshal(variants[0])\n")
+	fmt.Fprint(buf, "\t}\n\n")
+	fmt.Fprint(buf, "\tmerged := make(map[string]json.RawMessage)\n")
+	fmt.Fprint(buf, "\tfor _, variant := range variants {\n")
+	fmt.Fprint(buf, "\t\tdata, err := json.Marshal(variant)\n")
+	fmt.Fprint(buf, "\t\tif err != nil {\n")
+	fmt.Fprint(buf, "\t\t\treturn nil, err\n")
+	fmt.Fprint(buf, "\t\t}\n\n")
+	fmt.Fprint(buf, "\t\tvar properties map[string]json.RawMessage\n")
+	fmt.Fprint(buf, "\t\tif err := json.Unmarshal(data, &properties); err != nil {\n")
+	fmt.Fprintf(buf, "\t\t\treturn nil, fmt.Errorf(\"encode %s: only object variants can be merged: %%w\", err)\n", a.Name)
+	fmt.Fprint(buf, "\t\t}\n\n")
+	fmt.Fprint(buf, "\t\tmaps.Copy(merged, properties)\n")
+	fmt.Fprint(buf, "\t}\n\n")
+	fmt.Fprint(buf, "\treturn json.Marshal(merged)\n")
+	fmt.Fprint(buf, "}\n\n")
 
-	// TODO: extract into helper
+	fmt.Fprintf(buf, "// Raw returns the raw JSON that [%s] was decoded from.\n", a.Name)
+	fmt.Fprintf(buf, "func (v *%s) Raw() json.RawMessage {\n", a.Name)
+	fmt.Fprint(buf, "\treturn v.raw\n")
+	fmt.Fprint(buf, "}\n\n")
+
+	for _, v := range a.Variants {
+		fmt.Fprintf(buf, "// As%s returns the %s variant of [%s], if the data matched it.\n", v.Name, v.Name, a.Name)
+		fmt.Fprintf(buf, "func (v *%s) As%s() (*%s, bool) {\n", a.Name, v.Name, v.Type)
+		fmt.Fprintf(buf, "\treturn v.%s, v.%s != nil\n", v.Name, v.Name)
+		fmt.Fprint(buf, "}\n\n")
+	}
+	return buf.String()
+}
+
+// writeUnmarshalJSON writes [json.Unmarshaler] implementation of the anyOf type. The data
+// are first decoded into every variant that they match exactly, i.e. that has all its
+// required properties present and decodes the data without unknown fields. If no variant
+// matches exactly, the data are an object combining the properties of multiple variants
+// and are decoded into every variant whose required properties are present.
+func (a *AnyOfDeclaration) writeUnmarshalJSON(buf *strings.Builder) {
+	fmt.Fprint(buf, "// UnmarshalJSON implements [json.Unmarshaler]. The data are decoded into every\n")
+	fmt.Fprint(buf, "// variant that they match without unknown fields. If there is no such variant,\n")
+	fmt.Fprint(buf, "// the data are decoded into every variant whose required properties they contain.\n")
+	fmt.Fprintf(buf, "func (v *%s) UnmarshalJSON(data []byte) error {\n", a.Name)
+	fmt.Fprintf(buf, "\t*v = %s{raw: append(json.RawMessage(nil), data...)}\n\n", a.Name)
+	fmt.Fprint(buf, "\tvar properties map[string]json.RawMessage\n")
+	fmt.Fprint(buf, "\t_ = json.Unmarshal(data, &properties)\n\n")
+	fmt.Fprint(buf, "\tfor _, strict := range []bool{true, false} {\n")
+	matched := make([]string, 0, len(a.Variants))
+	for _, variant := range a.Variants {
+		matched = append(matched, fmt.Sprintf("v.%s != nil", variant.Name))
+
+		// variants without required properties would match any object if decoded leniently
+		conditions := []string{"strict"}
+		if len(variant.Required) > 0 {
+			conditions = make([]string, 0, len(variant.Required))
+			for _, property := range variant.Required {
+				conditions = append(conditions, fmt.Sprintf("properties[%q] != nil", property))
+			}
+		}
+
+		fmt.Fprintf(buf, "\t\tif %s {\n", strings.Join(conditions, " && "))
+		fmt.Fprintf(buf, "\t\t\tvar variant %s\n", variant.Type)
+		fmt.Fprint(buf, "\t\t\tdec := json.NewDecoder(bytes.NewReader(data))\n")
+		if len(variant.Required) > 0 {
+			fmt.Fprint(buf, "\t\t\tif strict {\n")
+			fmt.Fprint(buf, "\t\t\t\tdec.DisallowUnknownFields()\n")
+			fmt.Fprint(buf, "\t\t\t}\n")
+		} else {
+			fmt.Fprint(buf, "\t\t\tdec.DisallowUnknownFields()\n")
+		}
+		fmt.Fprint(buf, "\t\t\tif err := dec.Decode(&variant); err == nil {\n")
+		fmt.Fprintf(buf, "\t\t\t\tv.%s = &variant\n", variant.Name)
+		fmt.Fprint(buf, "\t\t\t}\n")
+		fmt.Fprint(buf, "\t\t}\n")
+	}
+	fmt.Fprintf(buf, "\n\t\tif %s {\n", strings.Join(matched, " || "))
+	fmt.Fprint(buf, "\t\t\treturn nil\n")
+	fmt.Fprint(buf, "\t\t}\n")
+	fmt.Fprint(buf, "\t}\n\n")
+	fmt.Fprintf(buf, "\treturn fmt.Errorf(\"decode %s: data does not match any variant\")\n", a.Name)
+	fmt.Fprint(buf, "}\n\n")
+}
+
+// GoName returns the name of the field in the generated struct.
+func (f *StructField) GoName() string {
+	name := f.Name
 	if strings.HasPrefix(name, "+") {
 		name = strings.Replace(name, "+", "Plus", 1)
 	}
@@ -74,18 +267,34 @@ func (f *StructField) String() string {
 		name = strings.Replace(name, "$", "", 1)
 	}
 
-	name = strcase.ToCamel(name)
-	if f.Pointer {
+	return strcase.ToCamel(name)
+}
+
+func (f *StructField) String() string {
+	buf := new(strings.Builder)
+	if f.Comment != "" {
+		fmt.Fprintf(buf, "// %s\n", f.Comment)
+	}
+	if f.Embedded {
+		fmt.Fprintf(buf, "\t%s", f.Type)
+		return buf.String()
+	}
+
+	name := f.GoName()
+	switch {
+	case f.Nullable:
+		fmt.Fprintf(buf, "\t%s nullable.Nullable[%s]", name, f.Type)
+	case f.Pointer:
 		fmt.Fprintf(buf, "\t%s *%s", name, f.Type)
-	} else {
+	default:
 		fmt.Fprintf(buf, "\t%s %s", name, f.Type)
 	}
 	if len(f.Tags) > 0 {
-		fmt.Fprint(buf, " `")
-		for k, v := range f.Tags {
-			fmt.Fprintf(buf, "%s:%q", k, strings.Join(v, ","))
+		tags := make([]string, 0, len(f.Tags))
+		for _, k := range slices.Sorted(maps.Keys(f.Tags)) {
+			tags = append(tags, fmt.Sprintf("%s:%q", k, strings.Join(f.Tags[k], ",")))
 		}
-		fmt.Fprint(buf, "`")
+		fmt.Fprintf(buf, " `%s`", strings.Join(tags, " "))
 	}
 
 	return buf.String()
@@ -112,8 +321,8 @@ func dereferenceSchema(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
 	if ref.Ref != "" || ref.Value == nil {
 		return ref
 	}
-	if len(ref.Value.AllOf) > 0 {
-		return dereferenceSchema(ref.Value.AllOf[0])
+	if member := singleAllOfMember(ref.Value); member != nil {
+		return dereferenceSchema(member)
 	}
 	return ref
 }
@@ -139,6 +348,10 @@ func paramToString(name string, param *openapi3.Parameter) string {
 
 	switch {
 	case schema.Value.Type.Is("string"):
+		// inlined enums have a type of their own
+		if len(schema.Value.Enum) != 0 {
+			return fmt.Sprintf("string(%s)", name)
+		}
 		switch schema.Value.Format {
 		case "date-time":
 			name = strings.TrimPrefix(name, "*")
@@ -149,6 +362,9 @@ func paramToString(name string, param *openapi3.Parameter) string {
 		case "time":
 			name = strings.TrimPrefix(name, "*")
 			return fmt.Sprintf("%s.String()", name)
+		case "password":
+			name = strings.TrimPrefix(name, "*")
+			return fmt.Sprintf("%s.Value()", name)
 		default:
 			return name
 		}
@@ -188,40 +404,6 @@ func paramToString(name string, param *openapi3.Parameter) string {
 	}
 }
 
-type toQueryValues struct {
-	Typ *TypeDeclaration
-}
-
-func (e toQueryValues) String() string {
-	buf := new(strings.Builder)
-	fmt.Fprintf(buf, "// QueryValues converts [%s] into [url.Values].\n", e.Typ.Name)
-	fmt.Fprintf(buf, "func (p *%s) QueryValues() url.Values {\n", e.Typ.Name)
-	fmt.Fprintf(buf, "\tq := make(url.Values)\n\n")
-	for _, f := range e.Typ.Fields {
-		name := strcase.ToCamel(f.Name)
-		if f.Parameter.Schema.Value.Type.Is("array") {
-			field := fmt.Sprintf("p.%s", name)
-			fmt.Fprintf(buf, "\tfor _, v := range %s {\n", field)
-			fmt.Fprintf(buf, "\t\tq.Add(%q, %s)\n", f.Name, paramToString("v", f.Parameter))
-			fmt.Fprintf(buf, "\t}\n")
-		} else {
-			if f.Parameter.Required {
-				field := fmt.Sprintf("p.%s", name)
-				fmt.Fprintf(buf, "\tq.Set(%q, %s)\n", f.Name, paramToString(field, f.Parameter))
-			} else {
-				fmt.Fprintf(buf, "\tif p.%s != nil {\n", name)
-				field := fmt.Sprintf("*p.%s", name)
-				fmt.Fprintf(buf, "\t\tq.Set(%q, %s)\n", f.Name, paramToString(field, f.Parameter))
-				fmt.Fprintf(buf, "\t}\n")
-			}
-		}
-		fmt.Fprint(buf, "\n")
-	}
-	fmt.Fprintf(buf, "\treturn q\n")
-	fmt.Fprint(buf, "}\n")
-	return buf.String()
-}
-
 type typeAssertionDeclaration struct {
 	typ string
 }
@@ -258,6 +440,32 @@ func (e errorImplementation) String() string {
 	return buf.String()
 }
 
+// unionErrorImplementation is used to generate `error` interface for oneOf and anyOf types
+// returned by error responses. The error message is taken from the first variant that is set.
+type unionErrorImplementation struct {
+	Name     string
+	Variants []OneOfVariant
+}
+
+func (e unionErrorImplementation) String() string {
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "func (e *%s) Error() string {\n", e.Name)
+	fmt.Fprint(buf, "\tswitch {\n")
+	for _, v := range e.Variants {
+		fmt.Fprintf(buf, "\tcase e.%s != nil:\n", v.Name)
+		fmt.Fprintf(buf, "\t\tif err, ok := any(e.%s).(error); ok {\n", v.Name)
+		fmt.Fprint(buf, "\t\t\treturn err.Error()\n")
+		fmt.Fprint(buf, "\t\t}\n")
+		fmt.Fprintf(buf, "\t\tdata, _ := json.Marshal(e.%s)\n", v.Name)
+		fmt.Fprintf(buf, "\t\treturn fmt.Sprintf(\"%s: %%s\", data)\n", e.Name)
+	}
+	fmt.Fprint(buf, "\tdefault:\n")
+	fmt.Fprintf(buf, "\t\treturn %q\n", e.Name)
+	fmt.Fprint(buf, "\t}\n")
+	fmt.Fprint(buf, "}\n")
+	return buf.String()
+}
+
 // staticErrorImplementation implements `error` for responses with empty schemas.
 type staticErrorImplementation struct {
 	Typ  string
@@ -0,0 +1,137 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// usesXML reports whether any operation of the specs sends or receives XML bodies,
+// in which case the generated types are tagged for XML encoding as well.
+func usesXML(spec *openapi3.T) bool {
+	if spec.Paths == nil {
+		return false
+	}
+
+	for _, path := range spec.Paths.Map() {
+		for _, op := range path.Operations() {
+			if op.RequestBody != nil && op.RequestBody.Value != nil && hasXMLMediaType(op.RequestBody.Value.Content) {
+				return true
+			}
+			if op.Responses == nil {
+				continue
+			}
+			for _, resp := range op.Responses.Map() {
+				if resp.Value != nil && hasXMLMediaType(resp.Value.Content) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// hasXMLMediaType reports whether the content has any XML media type.
+func hasXMLMediaType(content openapi3.Content) bool {
+	for name := range content {
+		if isXMLMediaType(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// xmlTag returns the `xml` struct tag of the schema property, honoring the XML object of the
+// property (see https://spec.openapis.org/oas/v3.1.0#xml-object). Arrays are encoded as a
+// sequence of elements named after the items, or wrapped in an element named after the property
+// if `wrapped` is set. The `prefix` is not supported by [encoding/xml], namespaced elements are
+// written with the namespace declared as the default namespace instead, which is equivalent.
+func xmlTag(property string, schema *openapi3.SchemaRef, optional bool) []string {
+	if schema == nil || schema.Value == nil {
+		return []string{property}
+	}
+
+	if isAdditionalPropertiesMap(schema.Value) {
+		// maps are not supported by encoding/xml
+		return []string{"-"}
+	}
+
+	// XML object of referenced schema describes the schema itself, not the property
+	var x openapi3.XML
+	if schema.Ref == "" && schema.Value.XML != nil {
+		x = *schema.Value.XML
+	}
+
+	name := property
+	if x.Name != "" {
+		name = x.Name
+	}
+
+	if isArraySchema(schema.Value) && schema.Value.Items != nil {
+		item := property
+		if items := schema.Value.Items.Value; items != nil && items.XML != nil && items.XML.Name != "" {
+			item = items.XML.Name
+		}
+
+		if x.Wrapped {
+			name += ">" + item
+		} else {
+			name = item
+		}
+	}
+
+	if x.Namespace != "" {
+		name = x.Namespace + " " + name
+	}
+
+	tag := []string{name}
+	switch {
+	case x.Attribute:
+		tag = append(tag, "attr")
+		if optional {
+			tag = append(tag, "omitempty")
+		}
+	case optional:
+		tag = append(tag, "omitempty")
+	}
+	return tag
+}
+
+// toXMLRoot generates method that encodes request body as XML element named after the schema
+// of the body, rather than after the Go type.
+type toXMLRoot struct {
+	Typ *TypeDeclaration
+	// Name of the root element.
+	Name string
+	// Namespace of the root element, empty if the element is not namespaced.
+	Namespace string
+}
+
+// newXMLRoot returns [toXMLRoot] for the type of request body with the schema. The element is named
+// after the XML object of the schema, the name of the referenced schema or the type, in this order.
+func newXMLRoot(typ *TypeDeclaration, schema *openapi3.SchemaRef) *toXMLRoot {
+	root := &toXMLRoot{Typ: typ, Name: typ.Name}
+	if schema.Ref != "" {
+		root.Name = strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+	}
+	if x := schema.Value.XML; x != nil {
+		if x.Name != "" {
+			root.Name = x.Name
+		}
+		root.Namespace = x.Namespace
+	}
+	return root
+}
+
+func (r toXMLRoot) String() string {
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "// MarshalXML encodes [%s] as XML element %q.\n", r.Typ.Name, r.Name)
+	fmt.Fprintf(buf, "func (b %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n", r.Typ.Name)
+	fmt.Fprintf(buf, "\ttype body %s\n", r.Typ.Name)
+	fmt.Fprintf(buf, "\tstart.Name = xml.Name{Space: %q, Local: %q}\n", r.Namespace, r.Name)
+	fmt.Fprint(buf, "\treturn e.EncodeElement(body(b), start)\n")
+	fmt.Fprint(buf, "}\n")
+	return buf.String()
+}
@@ -0,0 +1,103 @@
+package builder
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestXMLTag(t *testing.T) {
+	item := openapi3.NewObjectSchema()
+	item.XML = &openapi3.XML{Name: "line"}
+
+	tests := []struct {
+		name     string
+		schema   *openapi3.Schema
+		ref      string
+		optional bool
+		want     []string
+	}{
+		{
+			name:   "property name",
+			schema: openapi3.NewStringSchema(),
+			want:   []string{"createdAt"},
+		},
+		{
+			name:     "renamed optional",
+			schema:   &openapi3.Schema{Type: &openapi3.Types{"number"}, XML: &openapi3.XML{Name: "total"}},
+			optional: true,
+			want:     []string{"total", "omitempty"},
+		},
+		{
+			name:   "attribute",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"string"}, XML: &openapi3.XML{Attribute: true}},
+			want:   []string{"createdAt", "attr"},
+		},
+		{
+			name:   "namespace",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"string"}, XML: &openapi3.XML{Namespace: "https://example.com/ns", Prefix: "ex"}},
+			want:   []string{"https://example.com/ns createdAt"},
+		},
+		{
+			name:   "unwrapped array",
+			schema: openapi3.NewArraySchema().WithItems(item),
+			want:   []string{"line"},
+		},
+		{
+			name: "wrapped array",
+			schema: &openapi3.Schema{
+				Type:  &openapi3.Types{"array"},
+				Items: item.NewRef(),
+				XML:   &openapi3.XML{Name: "lines", Wrapped: true},
+			},
+			want: []string{"lines>line"},
+		},
+		{
+			name:   "xml of referenced schema",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"object"}, XML: &openapi3.XML{Name: "order"}},
+			ref:    "#/components/schemas/Order",
+			want:   []string{"createdAt"},
+		},
+		{
+			name:   "map",
+			schema: openapi3.NewObjectSchema().WithAnyAdditionalProperties(),
+			want:   []string{"-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := xmlTag("createdAt", &openapi3.SchemaRef{Ref: tt.ref, Value: tt.schema}, tt.optional)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("xmlTag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXMLRoot(t *testing.T) {
+	schema := openapi3.NewObjectSchema()
+	typ := &TypeDeclaration{Name: "CreateOrderBody"}
+
+	if root := newXMLRoot(typ, &openapi3.SchemaRef{Ref: "#/components/schemas/Order", Value: schema}); root.Name != "Order" {
+		t.Errorf("expected root named after the referenced schema, got %q", root.Name)
+	}
+	if root := newXMLRoot(typ, schema.NewRef()); root.Name != "CreateOrderBody" {
+		t.Errorf("expected root named after the type, got %q", root.Name)
+	}
+
+	schema.XML = &openapi3.XML{Name: "order", Namespace: "https://example.com/orders"}
+	root := newXMLRoot(typ, &openapi3.SchemaRef{Ref: "#/components/schemas/Order", Value: schema})
+
+	want := `// MarshalXML encodes [CreateOrderBody] as XML element "order".
+func (b CreateOrderBody) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type body CreateOrderBody
+	start.Name = xml.Name{Space: "https://example.com/orders", Local: "order"}
+	return e.EncodeElement(body(b), start)
+}
+`
+	if got := root.String(); got != want {
+		t.Errorf("unexpected MarshalXML:\n got: %s\nwant: %s", got, want)
+	}
+}
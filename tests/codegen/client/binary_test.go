@@ -0,0 +1,20 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReadText(t *testing.T) {
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader("ok"))}
+	if got, err := ReadText(resp); err != nil || got != "ok" {
+		t.Fatalf("unexpected text: %q, %v", got, err)
+	}
+
+	resp = &http.Response{Body: io.NopCloser(strings.NewReader(strings.Repeat("x", MaxTextSize+1)))}
+	if _, err := ReadText(resp); err == nil {
+		t.Fatalf("expected error for text body larger than %d bytes", MaxTextSize)
+	}
+}
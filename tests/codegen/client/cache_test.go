@@ -0,0 +1,261 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// etagServer serves a JSON document with an ETag and responds with 304 Not Modified
+// to conditional requests with a matching ETag.
+type etagServer struct {
+	mu          sync.Mutex
+	requests    int
+	conditional int
+}
+
+func (s *etagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	w.Header().Set("ETag", `"v1"`)
+	w.Header().Set("X-Request-Id", r.Header.Get("X-Test-Request"))
+	if r.Header.Get("If-None-Match") == `"v1"` {
+		s.conditional++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", r.Header.Get("Accept"))
+	_, _ = io.WriteString(w, `{"id":"card_1"}`)
+}
+
+func newCacheClient(t *testing.T, srv *etagServer) *Client {
+	t.Helper()
+
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	c, err := NewWithError(WithBaseURL(ts.URL), WithCache(NewMemoryCache(10)))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return c
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return string(body)
+}
+
+func TestCacheNotModified(t *testing.T) {
+	srv := &etagServer{}
+	c := newCacheClient(t, srv)
+
+	for i, id := range []string{"req_1", "req_2"} {
+		resp, err := c.Call(context.Background(), http.MethodGet, "/cards/card_1",
+			WithHeader("Accept", "application/json"), WithHeader("X-Test-Request", id))
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected cached response with status 200, got %d", resp.StatusCode)
+		}
+		if got, want := readBody(t, resp), `{"id":"card_1"}`; got != want {
+			t.Fatalf("unexpected body of call %d:\n got: %s\nwant: %s", i, got, want)
+		}
+		// headers of the 304 response are merged into the cached response
+		if got := resp.Header.Get("X-Request-Id"); got != id {
+			t.Fatalf("expected updated header %q, got %q", id, got)
+		}
+		if got, want := resp.Header.Get("Content-Type"), "application/json"; got != want {
+			t.Fatalf("expected cached header %q, got %q", want, got)
+		}
+	}
+
+	if srv.requests != 2 || srv.conditional != 1 {
+		t.Fatalf("expected second request to be conditional, got %d requests, %d conditional", srv.requests, srv.conditional)
+	}
+}
+
+func TestCacheVariant(t *testing.T) {
+	srv := &etagServer{}
+	c := newCacheClient(t, srv)
+
+	for _, accept := range []string{"application/json", "application/xml"} {
+		resp, err := c.Call(context.Background(), http.MethodGet, "/cards/card_1", WithHeader("Accept", accept))
+		if err != nil {
+			t.Fatalf("call: %v", err)
+		}
+		_ = readBody(t, resp)
+	}
+
+	if srv.conditional != 0 {
+		t.Fatalf("expected response to a different Accept header not to be revalidated, got %d conditional requests", srv.conditional)
+	}
+}
+
+// keyStore is a [CacheStore] that records the keys of the cached entries.
+type keyStore struct {
+	*MemoryCache
+	mu   sync.Mutex
+	keys []string
+}
+
+func (s *keyStore) Set(key string, entry *CacheEntry) {
+	s.mu.Lock()
+	s.keys = append(s.keys, key)
+	s.mu.Unlock()
+	s.MemoryCache.Set(key, entry)
+}
+
+func TestCacheCredentials(t *testing.T) {
+	srv := &etagServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	store := &keyStore{MemoryCache: NewMemoryCache(10)}
+	call := func(opts ...ClientOption) {
+		t.Helper()
+
+		c, err := NewWithError(append([]ClientOption{WithBaseURL(ts.URL), WithCache(store)}, opts...)...)
+		if err != nil {
+			t.Fatalf("new client: %v", err)
+		}
+
+		resp, err := c.Call(context.Background(), http.MethodGet, "/cards/card_1", WithHeader("Accept", "application/json"),
+			WithSecurity(SecurityRequirement{"merchantKey"}, SecurityRequirement{"queryKey"}))
+		if err != nil {
+			t.Fatalf("call: %v", err)
+		}
+		_ = readBody(t, resp)
+	}
+
+	call(WithMerchantKey("merchant_a"))
+	call(WithMerchantKey("merchant_b"))
+	if srv.conditional != 0 {
+		t.Fatalf("expected response to a different API key not to be revalidated, got %d conditional requests", srv.conditional)
+	}
+
+	call(WithQueryKey("secret"))
+	for _, key := range store.keys {
+		if strings.Contains(key, "secret") {
+			t.Fatalf("expected cache key without credentials, got %s", key)
+		}
+	}
+}
+
+func TestCacheMaxBodySize(t *testing.T) {
+	body := `"` + strings.Repeat("a", MaxCacheBodySize) + `"`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}))
+	defer ts.Close()
+
+	store := NewMemoryCache(10)
+	c, err := NewWithError(WithBaseURL(ts.URL), WithCache(store))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	resp, err := c.Call(context.Background(), http.MethodGet, "/documents/1")
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if got := readBody(t, resp); got != body {
+		t.Fatalf("expected the whole body, got %d bytes, want %d", len(got), len(body))
+	}
+	if store.Len() != 0 {
+		t.Fatalf("expected response larger than %d bytes not to be cached", MaxCacheBodySize)
+	}
+}
+
+func TestCacheDo(t *testing.T) {
+	srv := &etagServer{}
+	c := newCacheClient(t, srv)
+
+	for range 2 {
+		req, err := c.NewRequest(context.Background(), http.MethodGet, "/cards/card_1", http.NoBody)
+		if err != nil {
+			t.Fatalf("new request: %v", err)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatalf("do: %v", err)
+		}
+		if got, want := readBody(t, resp), `{"id":"card_1"}`; got != want {
+			t.Fatalf("unexpected body:\n got: %s\nwant: %s", got, want)
+		}
+		if req.Header.Get("If-None-Match") != "" {
+			t.Fatal("expected the request of the caller not to be modified")
+		}
+	}
+
+	if srv.conditional != 1 {
+		t.Fatalf("expected requests sent with Do to be revalidated, got %d conditional requests", srv.conditional)
+	}
+}
+
+func TestCacheWithoutCache(t *testing.T) {
+	srv := &etagServer{}
+	c := newCacheClient(t, srv)
+
+	for range 2 {
+		resp, err := c.Call(context.Background(), http.MethodGet, "/cards/card_1",
+			WithHeader("Accept", "application/json"), WithoutCache())
+		if err != nil {
+			t.Fatalf("call: %v", err)
+		}
+		_ = readBody(t, resp)
+	}
+
+	if srv.conditional != 0 {
+		t.Fatalf("expected requests bypassing the cache to be unconditional, got %d conditional requests", srv.conditional)
+	}
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", &CacheEntry{StatusCode: http.StatusOK})
+	cache.Set("b", &CacheEntry{StatusCode: http.StatusOK})
+
+	// a becomes the most recently used entry
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected entry a to be cached")
+	}
+
+	cache.Set("c", &CacheEntry{StatusCode: http.StatusOK})
+
+	if cache.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", cache.Len())
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Fatal("expected least recently used entry b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Fatalf("expected entry %s to be cached", key)
+		}
+	}
+
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok || cache.Len() != 1 {
+		t.Fatal("expected entry a to be deleted")
+	}
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewRequestEscapedPath(t *testing.T) {
+	c, err := NewWithError(WithBaseURL("https://api.example.com/v1/"))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	req, err := c.NewRequest(context.Background(), http.MethodGet, "/files/a%2Fb%25", http.NoBody)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if got, want := req.URL.String(), "https://api.example.com/v1/files/a%2Fb%25"; got != want {
+		t.Fatalf("expected escaped path to be preserved:\n got: %s\nwant: %s", got, want)
+	}
+	if got, want := req.URL.Path, "/v1/files/a/b%"; got != want {
+		t.Fatalf("unexpected unescaped path:\n got: %s\nwant: %s", got, want)
+	}
+
+	if _, err := c.NewRequest(context.Background(), http.MethodGet, "/files/100%", http.NoBody); err == nil {
+		t.Fatal("expected error for path with invalid escape sequence")
+	}
+}
+
+func TestWithAPIKey(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	c, err := NewWithError(WithBaseURL(srv.URL), WithAPIKey("secret"))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	resp, err := c.Call(context.Background(), http.MethodGet, "/cards", WithSecurity(SecurityRequirement{"apiKey"}))
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got, want := authorization, "Bearer secret"; got != want {
+		t.Fatalf("expected the deprecated option to configure the default scheme:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestDoAuthorizes(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	c, err := NewWithError(WithBaseURL(srv.URL), WithOauth2("token"))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	req, err := c.NewRequest(context.Background(), http.MethodGet, "/cards", http.NoBody)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got, want := authorization, "Bearer token"; got != want {
+		t.Fatalf("expected the request to be authorized:\n got: %s\nwant: %s", got, want)
+	}
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Fatalf("expected the request of the caller not to be modified, got %q", got)
+	}
+
+	resp, err = c.Call(context.Background(), http.MethodGet, "/cards", WithSecurity())
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if authorization != "" {
+		t.Fatalf("expected the request without security requirements not to be authorized, got %q", authorization)
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	c, err := NewWithError()
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	req, err := c.NewRequest(context.Background(), http.MethodGet, "/cards", http.NoBody)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if got, want := req.Header.Get("User-Agent"), "codegen/"+version; got != want {
+		t.Fatalf("unexpected default user agent:\n got: %s\nwant: %s", got, want)
+	}
+
+	c, err = NewWithError(WithUserAgent("my-app/1.0"))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	req, err = c.NewRequest(context.Background(), http.MethodGet, "/cards", http.NoBody)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if got, want := req.Header.Get("User-Agent"), "my-app/1.0"; got != want {
+		t.Fatalf("unexpected user agent:\n got: %s\nwant: %s", got, want)
+	}
+}
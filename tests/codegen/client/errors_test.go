@@ -0,0 +1,99 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type testError struct {
+	Code string `json:"code"`
+}
+
+func (e *testError) Error() string {
+	return e.Code
+}
+
+func errorResponse(code int, contentType, body string) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": {contentType}, "X-Request-Id": {"req_1"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	resp := errorResponse(http.StatusNotFound, "application/json", `{"code":"NOT_FOUND"}`)
+
+	var err error = NewAPIError(resp, "Card not found.", &testError{})
+
+	var payload *testError
+	if !errors.As(err, &payload) || payload.Code != "NOT_FOUND" {
+		t.Fatalf("expected decoded payload to be reachable with errors.As, got %v", payload)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+	if got, want := string(apiErr.Body), `{"code":"NOT_FOUND"}`; got != want {
+		t.Fatalf("unexpected body:\n got: %s\nwant: %s", got, want)
+	}
+	if got, want := err.Error(), "404 Not Found: NOT_FOUND (request id: req_1)"; got != want {
+		t.Fatalf("unexpected message:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestNewAPIErrorProblem(t *testing.T) {
+	resp := errorResponse(http.StatusConflict, "application/problem+json", `{"title":"Conflict","detail":"Card already exists.","code":"DUPLICATE"}`)
+
+	err := NewAPIError(resp, "", &Problem{})
+
+	var problem *Problem
+	if !errors.As(err, &problem) {
+		t.Fatalf("expected problem details to be reachable with errors.As, got %v", err)
+	}
+	if problem.Detail != "Card already exists." || problem.Extensions["code"] != "DUPLICATE" {
+		t.Fatalf("unexpected problem details: %+v", problem)
+	}
+}
+
+func TestNewAPIErrorLimitsBody(t *testing.T) {
+	resp := errorResponse(http.StatusBadGateway, "text/plain", strings.Repeat("x", MaxErrorBodySize+1))
+
+	err := NewAPIError(resp, "", nil)
+	if got := len(err.Body); got != MaxErrorBodySize {
+		t.Fatalf("expected body to be truncated to %d bytes, got %d", MaxErrorBodySize, got)
+	}
+}
+
+func TestIsStatus(t *testing.T) {
+	for name, tc := range map[string]struct {
+		code int
+		is   func(error) bool
+	}{
+		"bad request":       {code: http.StatusBadRequest, is: IsBadRequest},
+		"unauthorized":      {code: http.StatusUnauthorized, is: IsUnauthorized},
+		"forbidden":         {code: http.StatusForbidden, is: IsForbidden},
+		"not found":         {code: http.StatusNotFound, is: IsNotFound},
+		"conflict":          {code: http.StatusConflict, is: IsConflict},
+		"too many requests": {code: http.StatusTooManyRequests, is: IsTooManyRequests},
+		"server error":      {code: http.StatusServiceUnavailable, is: IsServerError},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := fmt.Errorf("get card: %w", NewAPIError(errorResponse(tc.code, "", ""), "", nil))
+			if !tc.is(err) {
+				t.Fatalf("expected wrapped error with status %d to match", tc.code)
+			}
+			if tc.is(NewAPIError(errorResponse(http.StatusTeapot, "", ""), "", nil)) {
+				t.Fatalf("expected error with status %d not to match", http.StatusTeapot)
+			}
+			if tc.is(errors.New("network error")) {
+				t.Fatal("expected error other than APIError not to match")
+			}
+		})
+	}
+}
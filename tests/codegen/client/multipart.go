@@ -0,0 +1,145 @@
+// Code generated by `go-sdk-gen`. DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// File is a file uploaded as a part of multipart/form-data request body.
+type File struct {
+	// Content of the file, read as the request is sent. If Content implements
+	// [io.Closer], it is closed once the content is read.
+	Content io.Reader
+	// Filename is the name of the file sent to the API.
+	Filename string
+	// ContentType is the media type of the file. Defaults to the content type
+	// defined by the API or "application/octet-stream".
+	ContentType string
+}
+
+// MultipartBody is a request body encoded as multipart/form-data, see [WithMultipartBody].
+type MultipartBody interface {
+	// WriteMultipart writes the parts of the body.
+	WriteMultipart(w *multipart.Writer) error
+}
+
+// WithMultipartBody returns a [RequestOption] that sets the request body as multipart/form-data
+// encoding of body. The body is streamed, that is the files are read as the request is sent, and
+// therefore requests with multipart body are never retried.
+func WithMultipartBody(body MultipartBody) RequestOption {
+	return func(r *request) error {
+		pr, pw := io.Pipe()
+		w := multipart.NewWriter(pw)
+
+		r.req.Body = &pipeBody{
+			pr: pr,
+			write: func() {
+				err := body.WriteMultipart(w)
+				if err == nil {
+					err = w.Close()
+				}
+				_ = pw.CloseWithError(err)
+			},
+		}
+		r.req.GetBody = nil
+		r.req.ContentLength = -1
+		r.req.Header.Set("Content-Type", w.FormDataContentType())
+		return nil
+	}
+}
+
+// pipeBody is a request body that is written as it is read. The writing starts only once
+// the body is read for the first time, so that no goroutine is leaked if the request is
+// never sent.
+type pipeBody struct {
+	once  sync.Once
+	pr    *io.PipeReader
+	write func()
+}
+
+func (b *pipeBody) Read(p []byte) (int, error) {
+	b.once.Do(func() { go b.write() })
+	return b.pr.Read(p)
+}
+
+func (b *pipeBody) Close() error {
+	return b.pr.Close()
+}
+
+// WriteMultipartFile writes the file as a part of multipart/form-data body. The contentType
+// is used if the file has no content type of its own.
+func WriteMultipartFile(w *multipart.Writer, name string, file File, contentType string) error {
+	if closer, ok := file.Content.(io.Closer); ok {
+		defer func() { _ = closer.Close() }()
+	}
+
+	if file.ContentType != "" {
+		contentType = file.ContentType
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(name), escapeQuotes(file.Filename)))
+	h.Set("Content-Type", contentType)
+
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return err
+	}
+
+	if file.Content == nil {
+		return nil
+	}
+
+	_, err = io.Copy(part, file.Content)
+	return err
+}
+
+// WriteMultipartValue writes the value as a part of multipart/form-data body with the content type.
+func WriteMultipartValue(w *multipart.Writer, name, value, contentType string) error {
+	part, err := createPart(w, name, contentType)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(part, value)
+	return err
+}
+
+// WriteMultipartJSON writes JSON encoding of v as a part of multipart/form-data body.
+// The contentType defaults to "application/json".
+func WriteMultipartJSON(w *multipart.Writer, name string, v any, contentType string) error {
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	part, err := createPart(w, name, contentType)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(part).Encode(v)
+}
+
+// createPart creates a form field part of multipart/form-data body with the content type.
+func createPart(w *multipart.Writer, name, contentType string) (io.Writer, error) {
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, escapeQuotes(name)))
+	h.Set("Content-Type", contentType)
+	return w.CreatePart(h)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,132 @@
+// Code generated by `go-sdk-gen`. DO NOT EDIT.
+
+package client
+
+import (
+	"encoding"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResponseMetadata receives the metadata of the response to a call, see [WithResponse].
+type ResponseMetadata interface {
+	// SetResponse sets the metadata from the response. The body of the response
+	// must not be read.
+	SetResponse(resp *http.Response)
+}
+
+// Response is the metadata of the response to a call: the status code and the headers.
+// Operations that declare response headers have a metadata type of their own that embeds
+// Response and exposes the declared headers as typed fields.
+type Response struct {
+	// StatusCode is the HTTP status code of the response, e.g. 200.
+	StatusCode int
+	// Header are the headers of the response.
+	Header http.Header
+	// HTTPResponse is the raw response. Its body is handled by the client and must not be read.
+	HTTPResponse *http.Response
+}
+
+// SetResponse implements [ResponseMetadata].
+func (r *Response) SetResponse(resp *http.Response) {
+	r.StatusCode = resp.StatusCode
+	r.Header = resp.Header
+	r.HTTPResponse = resp
+}
+
+// WithResponse returns a [RequestOption] that sets the metadata of the response to the call
+// into meta, e.g. a [Response]. The metadata is set for error responses as well, and if the
+// request is retried, it is the metadata of the last response.
+func WithResponse(meta ResponseMetadata) RequestOption {
+	return func(r *request) error {
+		r.response = meta
+		return nil
+	}
+}
+
+// HeaderValue returns the value of the header parsed as T, nil if the header is not present
+// or its value cannot be parsed. Supported types are strings, integers, floats, booleans,
+// [time.Time] (RFC 3339 or HTTP date) and types that implement [encoding.TextUnmarshaler].
+func HeaderValue[T any](header http.Header, key string) *T {
+	value := header.Get(key)
+	if value == "" {
+		return nil
+	}
+
+	var v T
+	if !parseHeaderValue(strings.TrimSpace(value), &v) {
+		return nil
+	}
+	return &v
+}
+
+// HeaderValues returns the comma-separated values of the header parsed as T (see [HeaderValue]),
+// nil if the header is not present. Values that cannot be parsed are left out.
+func HeaderValues[T any](header http.Header, key string) []T {
+	var values []T
+	for _, line := range header.Values(key) {
+		for _, value := range strings.Split(line, ",") {
+			var v T
+			if parseHeaderValue(strings.TrimSpace(value), &v) {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// parseHeaderValue parses the value of a header into v, a pointer to a value of supported type.
+// Reports whether the value was parsed.
+func parseHeaderValue(value string, v any) bool {
+	switch v := v.(type) {
+	case *time.Time:
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			*v = t
+			return true
+		}
+		t, err := http.ParseTime(value)
+		if err != nil {
+			return false
+		}
+		*v = t
+		return true
+	case encoding.TextUnmarshaler:
+		return v.UnmarshalText([]byte(value)) == nil
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, rv.Type().Bits())
+		if err != nil {
+			return false
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, rv.Type().Bits())
+		if err != nil {
+			return false
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, rv.Type().Bits())
+		if err != nil {
+			return false
+		}
+		rv.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return false
+		}
+		rv.SetBool(b)
+	default:
+		return false
+	}
+	return true
+}
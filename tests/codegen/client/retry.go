@@ -0,0 +1,195 @@
+// Code generated by `go-sdk-gen`. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how the client retries failed requests, see [WithRetryPolicy].
+// Requests are retried when they fail with a network error or when the API responds
+// with one of the [RetryPolicy.StatusCodes].
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Requests are not retried if MaxAttempts is less than 2.
+	MaxAttempts int
+	// InitialBackoff is the time to wait before the first retry. The backoff is
+	// multiplied by [RetryPolicy.Multiplier] for every following retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum time to wait between two attempts. If the API asks to
+	// retry later than MaxBackoff using the `Retry-After` header, the request is not retried.
+	// There is no limit if zero.
+	MaxBackoff time.Duration
+	// Multiplier of the backoff after each retry, 2 if zero.
+	Multiplier float64
+	// Jitter is the fraction (between 0 and 1) of the backoff that is randomized
+	// to spread the retries of concurrent requests.
+	Jitter float64
+	// StatusCodes are the response status codes that are retried.
+	StatusCodes []int
+	// RetryNonIdempotent enables retries of requests with non-idempotent methods
+	// (e.g. POST). By default only requests with idempotent methods and requests sent
+	// with an idempotency key (see [WithIdempotency]) are retried.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is a [RetryPolicy] with reasonable defaults that retries requests
+// up to 3 times on network errors and responses with status 429, 502, 503 and 504.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	StatusCodes: []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// WithRetryPolicy returns a [ClientOption] that configures the client to retry failed
+// requests according to the policy. Requests are not retried by default.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// canRetry reports whether the request can be retried under the policy. Requests
+// with a body can only be retried if the body can be rewound using [http.Request.GetBody].
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	return p.RetryNonIdempotent || isIdempotent(req) || req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// shouldRetry reports whether the attempt that resulted in resp or err should be retried.
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	return slices.Contains(p.StatusCodes, resp.StatusCode)
+}
+
+// backoff returns the time to wait before the retry that follows the attempt
+// (0 for the first attempt).
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		backoff -= backoff * min(p.Jitter, 1) * rand.Float64()
+	}
+
+	return time.Duration(backoff)
+}
+
+// isIdempotent reports whether the method of the request is idempotent as defined
+// by RFC 9110.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the delay requested by the `Retry-After` header of the response,
+// given either in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// do sends the request, retrying it according to the retry policy of the client.
+func (c *Client) do(r *request) (*http.Response, error) {
+	policy := c.retryPolicy
+	if !policy.canRetry(r.req) {
+		return r.httpClient.Do(r.req)
+	}
+
+	ctx := r.req.Context()
+	for attempt := 0; ; attempt++ {
+		req := r.req
+		if attempt > 0 {
+			req = r.req.Clone(ctx)
+			if r.req.GetBody != nil {
+				body, err := r.req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("rewind request body: %s", err.Error())
+				}
+				req.Body = body
+			}
+		}
+
+		resp, err := r.httpClient.Do(req)
+		if attempt+1 >= policy.MaxAttempts || !policy.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt)
+		if delay, ok := retryAfter(resp); ok {
+			if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+				return resp, nil
+			}
+			wait = delay
+		}
+
+		if resp != nil {
+			// drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is an HTTP handler that responds with the status codes in order and
+// records the received requests.
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	header   http.Header
+	bodies   []string
+	keys     []string
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	rec.bodies = append(rec.bodies, string(body))
+	rec.keys = append(rec.keys, r.Header.Get(IdempotencyKeyHeader))
+
+	status := rec.statuses[min(len(rec.bodies), len(rec.statuses))-1]
+	if status != http.StatusOK {
+		for key, values := range rec.header {
+			w.Header()[key] = values
+		}
+	}
+	w.WriteHeader(status)
+}
+
+func (rec *recorder) attempts() int {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return len(rec.bodies)
+}
+
+func newRetryClient(t *testing.T, handler http.Handler, policy RetryPolicy) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := NewWithError(WithBaseURL(srv.URL), WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return c
+}
+
+func TestRetryTooManyRequestsRetryAfter(t *testing.T) {
+	rec := &recorder{
+		statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+		header:   http.Header{"Retry-After": {"1"}},
+	}
+	// the backoff is too long for the test to pass unless Retry-After is honored
+	c := newRetryClient(t, rec, RetryPolicy{
+		MaxAttempts:    2,
+		InitialBackoff: time.Hour,
+		StatusCodes:    []int{http.StatusTooManyRequests},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := c.Call(ctx, http.MethodGet, "/cards")
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || rec.attempts() != 2 {
+		t.Fatalf("expected request to succeed on second attempt, got %d after %d attempts", resp.StatusCode, rec.attempts())
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected retry after 1s, retried after %s", elapsed)
+	}
+}
+
+func TestRetryAfterExceedsMaxBackoff(t *testing.T) {
+	rec := &recorder{
+		statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+		header:   http.Header{"Retry-After": {"120"}},
+	}
+	c := newRetryClient(t, rec, RetryPolicy{
+		MaxAttempts: 2,
+		MaxBackoff:  time.Second,
+		StatusCodes: []int{http.StatusTooManyRequests},
+	})
+
+	resp, err := c.Call(context.Background(), http.MethodGet, "/cards")
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests || rec.attempts() != 1 {
+		t.Fatalf("expected the response to be returned without retry, got %d after %d attempts", resp.StatusCode, rec.attempts())
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		StatusCodes: []int{http.StatusServiceUnavailable},
+	}
+
+	rec := &recorder{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+	c := newRetryClient(t, rec, policy)
+
+	resp, err := c.Call(context.Background(), http.MethodPost, "/cards", WithJSONBody(map[string]string{"id": "card_1"}))
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable || rec.attempts() != 1 {
+		t.Fatalf("expected POST not to be retried, got %d after %d attempts", resp.StatusCode, rec.attempts())
+	}
+
+	rec = &recorder{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+	c = newRetryClient(t, rec, policy)
+
+	resp, err = c.Call(context.Background(), http.MethodPost, "/cards", WithJSONBody(map[string]string{"id": "card_1"}), WithIdempotency())
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || rec.attempts() != 2 {
+		t.Fatalf("expected POST with idempotency key to be retried, got %d after %d attempts", resp.StatusCode, rec.attempts())
+	}
+	if rec.bodies[0] == "" || rec.bodies[1] != rec.bodies[0] {
+		t.Fatalf("expected the body to be rewound for the retry, got %q", rec.bodies)
+	}
+	if rec.keys[0] == "" || rec.keys[1] != rec.keys[0] {
+		t.Fatalf("expected the same idempotency key on every attempt, got %q", rec.keys)
+	}
+}
+
+func TestRetryCanceledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &recorder{statuses: []int{http.StatusServiceUnavailable}}
+	c := newRetryClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.ServeHTTP(w, r)
+		// cancel once the client waits for the retry
+		time.AfterFunc(50*time.Millisecond, cancel)
+	}), RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Hour,
+		StatusCodes:    []int{http.StatusServiceUnavailable},
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Call(ctx, http.MethodGet, "/cards")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the backoff to be interrupted by the cancellation")
+	}
+
+	if rec.attempts() != 1 {
+		t.Fatalf("expected a single attempt, got %d", rec.attempts())
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		if got := policy.backoff(attempt); got != want {
+			t.Fatalf("unexpected backoff of attempt %d: got %s, want %s", attempt, got, want)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for range 100 {
+		if got := policy.backoff(1); got < time.Second || got > 2*time.Second {
+			t.Fatalf("expected backoff with jitter between 1s and 2s, got %s", got)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	for name, tc := range map[string]struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		"seconds":  {value: "3", want: 3 * time.Second, ok: true},
+		"negative": {value: "-3", want: 0, ok: true},
+		"date":     {value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), want: 0, ok: true},
+		"invalid":  {value: "soon"},
+		"missing":  {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tc.value != "" {
+				resp.Header.Set("Retry-After", tc.value)
+			}
+
+			got, ok := retryAfter(resp)
+			if got != tc.want || ok != tc.ok {
+				t.Fatalf("unexpected delay: got %s, %t, want %s, %t", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}}}
+	if got, ok := retryAfter(resp); !ok || got < 58*time.Minute || got > time.Hour {
+		t.Fatalf("expected delay until the date, got %s", got)
+	}
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewWithServer(t *testing.T) {
+	c, err := NewWithError(WithServer(ServerSandboxRegional, map[string]string{"region": "us"}))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	if got, want := c.base.String(), "https://us.sandbox.example.com/v1"; got != want {
+		t.Fatalf("unexpected base url:\n got: %s\nwant: %s", got, want)
+	}
+
+	_, err = NewWithError(WithServer(ServerSandboxRegional, map[string]string{"region": "apac"}))
+	if err == nil || !strings.Contains(err.Error(), `invalid value "apac" of server variable "region"`) {
+		t.Fatalf("expected error for value of server variable out of enum, got %v", err)
+	}
+
+	_, err = NewWithError(WithServer(ServerProduction, map[string]string{"region": "eu"}))
+	if err == nil || !strings.Contains(err.Error(), `unknown server variable "region"`) {
+		t.Fatalf("expected error for unknown server variable, got %v", err)
+	}
+}
+
+func TestNewSkipsFailingOptions(t *testing.T) {
+	c := New(WithServer(ServerSandboxRegional, map[string]string{"region": "apac"}), WithUserAgent("my-app/1.0"))
+	if got, want := c.base.String(), APIUrl; got != want {
+		t.Fatalf("expected the failing option to be skipped:\n got: %s\nwant: %s", got, want)
+	}
+	if got, want := c.userAgent, "my-app/1.0"; got != want {
+		t.Fatalf("expected the other options to be applied:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+// roundTripFunc is an [http.RoundTripper] that responds to the requests with fn.
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+func TestWithOperationServer(t *testing.T) {
+	var got string
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		got = r.URL.String()
+		return &http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+	})}
+	search := Server{
+		URL:       "https://{region}.search.example.com",
+		Variables: map[string]ServerVariable{"region": {Default: "eu", Enum: []string{"eu", "us"}}},
+	}
+
+	for name, tc := range map[string]struct {
+		opts []ClientOption
+		req  RequestOption
+		want string
+	}{
+		"default variables": {
+			req:  WithOperationServer(search),
+			want: "https://eu.search.example.com/search?q=1",
+		},
+		"variables of the server of the client": {
+			opts: []ClientOption{WithServer(ServerSandboxRegional, map[string]string{"region": "us"})},
+			req:  WithOperationServer(search),
+			want: "https://us.search.example.com/search?q=1",
+		},
+		"server variables": {
+			opts: []ClientOption{WithServerVariables(map[string]string{"region": "us", "version": "v2"})},
+			req:  WithOperationServer(search),
+			want: "https://us.search.example.com/search?q=1",
+		},
+		"base url": {
+			opts: []ClientOption{WithBaseURL("http://localhost:8080/v1")},
+			req:  WithOperationServer(search),
+			want: "http://localhost:8080/v1/search?q=1",
+		},
+		"relative server url": {
+			req:  WithServerURL("/files"),
+			want: "https://api.example.com/files/search?q=1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewWithError(append(tc.opts, WithClient(httpClient))...)
+			if err != nil {
+				t.Fatalf("new client: %v", err)
+			}
+
+			resp, err := c.Call(context.Background(), http.MethodGet, "/search", WithQueryValues(url.Values{"q": {"1"}}), tc.req)
+			if err != nil {
+				t.Fatalf("call: %v", err)
+			}
+			_ = resp.Body.Close()
+
+			if got != tc.want {
+				t.Fatalf("unexpected url:\n got: %s\nwant: %s", got, tc.want)
+			}
+		})
+	}
+
+	c, err := NewWithError(WithServerVariables(map[string]string{"region": "apac"}), WithClient(httpClient))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	if _, err := c.Call(context.Background(), http.MethodGet, "/search", WithOperationServer(search)); err == nil {
+		t.Fatal("expected error for value of server variable out of enum")
+	}
+}
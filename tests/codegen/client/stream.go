@@ -0,0 +1,331 @@
+// Code generated by `go-sdk-gen`. DO NOT EDIT.
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxStreamLine is the maximum length of a line of streamed response body.
+	maxStreamLine = 4 << 20
+	// defaultReconnectDelay is the delay before reconnecting to Server-Sent Events stream,
+	// unless the API sets another one using the `retry` field.
+	defaultReconnectDelay = 3 * time.Second
+	// maxReconnectAttempts is the maximum number of consecutive attempts to reconnect
+	// to Server-Sent Events stream.
+	maxReconnectAttempts = 5
+)
+
+// Stream is a stream of items of type T sent by the API as Server-Sent Events (`text/event-stream`)
+// or newline delimited JSON (`application/x-ndjson`, `application/jsonl`). The items are decoded
+// as they are received and the stream must be closed by the caller:
+//
+//	defer stream.Close()
+//	for stream.Next() {
+//		item := stream.Current()
+//		// ...
+//	}
+//	if err := stream.Err(); err != nil {
+//		// ...
+//	}
+//
+// Alternatively, iterate over the items using [Stream.All].
+type Stream[T any] struct {
+	reader  streamReader
+	decode  func(data []byte, v *T) error
+	current T
+	err     error
+}
+
+// streamReader reads the raw items of a stream.
+type streamReader interface {
+	// next returns the next item, [io.EOF] at the end of the stream.
+	next() ([]byte, error)
+	close() error
+}
+
+// NewStream returns [Stream] of newline delimited JSON items read from the body of the response.
+func NewStream[T any](resp *http.Response) *Stream[T] {
+	return &Stream[T]{
+		reader: &lineReader{body: resp.Body, scanner: newStreamScanner(resp.Body)},
+		decode: func(data []byte, v *T) error { return json.Unmarshal(data, v) },
+	}
+}
+
+// NewEventStream returns [Stream] of the data of Server-Sent Events read from the body of the response.
+// The data are decoded as JSON, unless T is string in which case the raw data are returned.
+//
+// If the connection is lost while reading the stream, reconnect is called to re-establish it, with
+// Last-Event-ID header set to the ID of the last event received. The stream ends when the API closes
+// the connection or responds with 204 No Content to the reconnection request.
+func NewEventStream[T any](resp *http.Response, reconnect func(opts ...RequestOption) (*http.Response, error)) *Stream[T] {
+	return &Stream[T]{
+		reader: &eventReader{
+			ctx:       resp.Request.Context(),
+			resp:      resp,
+			scanner:   newStreamScanner(resp.Body),
+			reconnect: reconnect,
+			retry:     defaultReconnectDelay,
+		},
+		decode: func(data []byte, v *T) error {
+			if s, ok := any(v).(*string); ok {
+				*s = string(data)
+				return nil
+			}
+			return json.Unmarshal(data, v)
+		},
+	}
+}
+
+// Next advances the stream to the next item, which is then available through [Stream.Current].
+// It returns false when the stream ends or an error occurs, see [Stream.Err].
+func (s *Stream[T]) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	data, err := s.reader.next()
+	if err != nil {
+		s.err = err
+		return false
+	}
+
+	var v T
+	if err := s.decode(data, &v); err != nil {
+		s.err = fmt.Errorf("decode stream item: %w", err)
+		return false
+	}
+
+	s.current = v
+	return true
+}
+
+// Current returns the current item of the stream.
+func (s *Stream[T]) Current() T {
+	return s.current
+}
+
+// Err returns the error that ended the stream, nil if the stream ended regularly.
+func (s *Stream[T]) Err() error {
+	if errors.Is(s.err, io.EOF) {
+		return nil
+	}
+	return s.err
+}
+
+// Close closes the stream.
+func (s *Stream[T]) Close() error {
+	return s.reader.close()
+}
+
+// LastEventID returns the ID of the last event received from Server-Sent Events stream, empty string
+// for other streams or if the API doesn't send the IDs of events.
+func (s *Stream[T]) LastEventID() string {
+	if r, ok := s.reader.(*eventReader); ok {
+		return r.lastEventID
+	}
+	return ""
+}
+
+// EventType returns the type of the current event of Server-Sent Events stream ("message" unless
+// set by the API), empty string for other streams.
+func (s *Stream[T]) EventType() string {
+	if r, ok := s.reader.(*eventReader); ok {
+		return r.eventType
+	}
+	return ""
+}
+
+// All returns an iterator over the items of the stream. The stream is closed once the iteration
+// is done.
+func (s *Stream[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer s.Close()
+
+		for s.Next() {
+			if !yield(s.current, nil) {
+				return
+			}
+		}
+
+		if err := s.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+func newStreamScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLine)
+	return scanner
+}
+
+// lineReader reads newline delimited items, skipping empty lines.
+type lineReader struct {
+	body    io.Closer
+	scanner *bufio.Scanner
+}
+
+func (r *lineReader) next() ([]byte, error) {
+	for r.scanner.Scan() {
+		if line := bytes.TrimSpace(r.scanner.Bytes()); len(line) != 0 {
+			return line, nil
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (r *lineReader) close() error {
+	return r.body.Close()
+}
+
+// eventReader reads the data of Server-Sent Events, reconnecting if the connection is lost.
+type eventReader struct {
+	ctx       context.Context
+	resp      *http.Response
+	scanner   *bufio.Scanner
+	reconnect func(opts ...RequestOption) (*http.Response, error)
+
+	lastEventID string
+	eventType   string
+	retry       time.Duration
+}
+
+func (r *eventReader) next() ([]byte, error) {
+	data, err := r.readEvent()
+	for attempt := 1; err != nil && r.shouldReconnect(err); attempt++ {
+		if attempt > maxReconnectAttempts {
+			return nil, fmt.Errorf("reconnect to event stream: %w", err)
+		}
+
+		if err = r.reconnectAfter(r.retry); err == nil {
+			data, err = r.readEvent()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// shouldReconnect reports whether to reconnect after the error, that is if the connection
+// was lost rather than closed by the API or the caller.
+func (r *eventReader) shouldReconnect(err error) bool {
+	var apiErr *APIError
+	return r.reconnect != nil && r.ctx.Err() == nil && !errors.Is(err, io.EOF) && !errors.As(err, &apiErr)
+}
+
+// readEvent reads the next event with data and returns the data. Events without data
+// are skipped as are incomplete events at the end of the stream.
+func (r *eventReader) readEvent() ([]byte, error) {
+	var (
+		data      []byte
+		hasData   bool
+		eventType string
+	)
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			if !hasData {
+				eventType = ""
+				continue
+			}
+
+			r.eventType = eventType
+			if r.eventType == "" {
+				r.eventType = "message"
+			}
+			return data, nil
+		}
+
+		if strings.HasPrefix(line, ":") {
+			// comment, e.g. keep-alive
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			if hasData {
+				data = append(data, '\n')
+			}
+			data = append(data, value...)
+			hasData = true
+		case "event":
+			eventType = value
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				r.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				r.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// reconnectAfter re-establishes the connection after the delay. Returns [io.EOF] if the API
+// responds with 204 No Content, signaling that the stream ended.
+func (r *eventReader) reconnectAfter(delay time.Duration) error {
+	_ = r.resp.Body.Close()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-r.ctx.Done():
+		return r.ctx.Err()
+	case <-timer.C:
+	}
+
+	var opts []RequestOption
+	if r.lastEventID != "" {
+		opts = append(opts, WithHeader("Last-Event-ID", r.lastEventID))
+	}
+
+	resp, err := r.reconnect(opts...)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		_ = resp.Body.Close()
+		return io.EOF
+	default:
+		defer resp.Body.Close()
+		return NewAPIError(resp, "", nil)
+	}
+
+	r.resp = resp
+	r.scanner = newStreamScanner(resp.Body)
+	return nil
+}
+
+func (r *eventReader) close() error {
+	return r.resp.Body.Close()
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// eventServer serves Server-Sent Events, dropping the connection after the first
+// response. Reconnection requests are answered with respond.
+type eventServer struct {
+	mu           sync.Mutex
+	lastEventIDs []string
+	respond      func(w http.ResponseWriter)
+}
+
+func (s *eventServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	attempt := len(s.lastEventIDs)
+	s.lastEventIDs = append(s.lastEventIDs, r.Header.Get("Last-Event-ID"))
+	s.mu.Unlock()
+
+	if attempt != 0 {
+		s.respond(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	fmt.Fprint(w, ": keep-alive\n\n")
+	// reconnect after 10ms instead of the default delay
+	fmt.Fprint(w, "retry: 10\n")
+	fmt.Fprint(w, "id: 1\n")
+	fmt.Fprint(w, "event: update\n")
+	fmt.Fprint(w, "data: {\"a\":1}\n")
+	fmt.Fprint(w, "data: second line\n\n")
+	fmt.Fprint(w, "id: 2\n")
+	fmt.Fprint(w, "data: incomplete")
+	w.(http.Flusher).Flush()
+	// drop the connection in the middle of the event
+	panic(http.ErrAbortHandler)
+}
+
+func (s *eventServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.lastEventIDs)
+}
+
+func newEventStream(t *testing.T, srv *httptest.Server) *Stream[string] {
+	t.Helper()
+
+	c, err := NewWithError(WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx := context.Background()
+	resp, err := c.Call(ctx, http.MethodGet, "/events")
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+
+	return NewEventStream[string](resp, func(opts ...RequestOption) (*http.Response, error) {
+		return c.Call(ctx, http.MethodGet, "/events", opts...)
+	})
+}
+
+func TestEventStreamReconnect(t *testing.T) {
+	events := &eventServer{respond: func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 3\ndata: after reconnect\n\n")
+	}}
+	srv := httptest.NewServer(events)
+	defer srv.Close()
+
+	stream := newEventStream(t, srv)
+	defer stream.Close()
+
+	if !stream.Next() {
+		t.Fatalf("expected first event, got error %v", stream.Err())
+	}
+	if got, want := stream.Current(), "{\"a\":1}\nsecond line"; got != want {
+		t.Fatalf("expected data lines to be joined:\n got: %q\nwant: %q", got, want)
+	}
+	if got, want := stream.EventType(), "update"; got != want {
+		t.Fatalf("got event type %q, want %q", got, want)
+	}
+	if got, want := stream.LastEventID(), "1"; got != want {
+		t.Fatalf("got last event id %q, want %q", got, want)
+	}
+
+	if !stream.Next() {
+		t.Fatalf("expected event after reconnect, got error %v", stream.Err())
+	}
+	if got, want := stream.Current(), "after reconnect"; got != want {
+		t.Fatalf("got data %q, want %q", got, want)
+	}
+	if got, want := stream.EventType(), "message"; got != want {
+		t.Fatalf("got event type %q, want %q", got, want)
+	}
+	if got, want := stream.LastEventID(), "3"; got != want {
+		t.Fatalf("got last event id %q, want %q", got, want)
+	}
+
+	if stream.Next() {
+		t.Fatalf("expected the stream to end, got %q", stream.Current())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("expected the stream to end without error, got %v", err)
+	}
+
+	// the id of the incomplete event is used to resume the stream
+	if got, want := events.requests(), []string{"", "2"}; !slices.Equal(got, want) {
+		t.Fatalf("unexpected Last-Event-ID headers:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestEventStreamNoContent(t *testing.T) {
+	events := &eventServer{respond: func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNoContent)
+	}}
+	srv := httptest.NewServer(events)
+	defer srv.Close()
+
+	stream := newEventStream(t, srv)
+	got := make([]string, 0)
+	for data, err := range stream.All() {
+		if err != nil {
+			t.Fatalf("stream: %v", err)
+		}
+		got = append(got, data)
+	}
+
+	if want := []string{"{\"a\":1}\nsecond line"}; !slices.Equal(got, want) {
+		t.Fatalf("got events %q, want %q", got, want)
+	}
+	if got, want := len(events.requests()), 2; got != want {
+		t.Fatalf("got %d requests, want %d", got, want)
+	}
+}
+
+func TestEventStreamReconnectError(t *testing.T) {
+	events := &eventServer{respond: func(w http.ResponseWriter) {
+		http.Error(w, "gone", http.StatusGone)
+	}}
+	srv := httptest.NewServer(events)
+	defer srv.Close()
+
+	stream := newEventStream(t, srv)
+	defer stream.Close()
+
+	if !stream.Next() {
+		t.Fatalf("expected first event, got error %v", stream.Err())
+	}
+	if stream.Next() {
+		t.Fatalf("expected the stream to end, got %q", stream.Current())
+	}
+	var apiErr *APIError
+	if err := stream.Err(); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusGone {
+		t.Fatalf("expected API error of the reconnection request, got %v", err)
+	}
+}
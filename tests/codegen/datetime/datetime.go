@@ -0,0 +1,137 @@
+// Code generated by `go-sdk-gen`. DO NOT EDIT.
+
+// Package datetime provides types for the `date` and `time` string formats
+// as defined by RFC 3339.
+package datetime
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date without time and time zone (`full-date` in RFC 3339),
+// e.g. 2024-12-31.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the [Date] in which the time t occurs in its location.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// ParseDate parses a date in the `YYYY-MM-DD` format.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("parse date %q: %w", s, err)
+	}
+
+	return DateOf(t), nil
+}
+
+// String returns the date in the `YYYY-MM-DD` format.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero reports whether the date is the zero value.
+func (d Date) IsZero() bool {
+	return d.Year == 0 && d.Month == 0 && d.Day == 0
+}
+
+// In returns the time at midnight of the date in the location loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (d *Date) UnmarshalText(data []byte) error {
+	date, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+
+	*d = date
+	return nil
+}
+
+// timeLayouts are the layouts accepted by [ParseTime], from the most common one.
+var timeLayouts = []string{
+	"15:04:05.999999999",
+	"15:04:05.999999999Z07:00",
+	"15:04",
+}
+
+// Time is a time of day without date and time zone (`partial-time` in RFC 3339),
+// e.g. 13:45:30.
+type Time struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOf returns the [Time] representing the time of day in which the time t
+// occurs in its location.
+func TimeOf(t time.Time) Time {
+	return Time{
+		Hour:       t.Hour(),
+		Minute:     t.Minute(),
+		Second:     t.Second(),
+		Nanosecond: t.Nanosecond(),
+	}
+}
+
+// ParseTime parses a time of day in the `hh:mm:ss` format with optional fractional seconds.
+// Time zone offset, if present, is ignored.
+func ParseTime(s string) (Time, error) {
+	for _, layout := range timeLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return TimeOf(t), nil
+		}
+	}
+
+	return Time{}, fmt.Errorf("parse time %q: expected format hh:mm:ss", s)
+}
+
+// String returns the time in the `hh:mm:ss` format, with fractional seconds if non-zero.
+func (t Time) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond == 0 {
+		return s
+	}
+
+	return s + time.Date(0, 1, 1, 0, 0, 0, t.Nanosecond, time.UTC).Format(".999999999")
+}
+
+// IsZero reports whether the time is the zero value (midnight).
+func (t Time) IsZero() bool {
+	return t == Time{}
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (t *Time) UnmarshalText(data []byte) error {
+	parsed, err := ParseTime(string(data))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
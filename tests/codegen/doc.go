@@ -1,3 +1,3 @@
 package codegen
 
-//go:generate go tool github.com/sumup/go-sdk-gen/cmd/go-sdk-gen generate --mod codegen --pkg codegen --name "Test Codegen" --force openapi.yaml
+//go:generate go tool github.com/sumup/go-sdk-gen/cmd/go-sdk-gen generate --mod codegen --pkg codegen --name "Test Codegen" --version-header Test-Codegen-Version --force openapi.yaml
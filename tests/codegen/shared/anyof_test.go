@@ -0,0 +1,54 @@
+package shared
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInstrumentUnmarshalJSON(t *testing.T) {
+	var card Instrument
+	if err := json.Unmarshal([]byte(`{"type":"card","last_four_digits":"4242"}`), &card); err != nil {
+		t.Fatalf("decode card: %v", err)
+	}
+	if _, ok := card.AsCard(); !ok {
+		t.Fatalf("expected card variant to be set")
+	}
+	if _, ok := card.AsBankTransfer(); ok {
+		t.Fatalf("expected bank transfer variant not to match card properties")
+	}
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		t.Fatalf("encode card: %v", err)
+	}
+	if got, want := string(data), `{"last_four_digits":"4242","type":"card"}`; got != want {
+		t.Fatalf("expected card to be encoded as is:\n got: %s\nwant: %s", got, want)
+	}
+
+	var combined Instrument
+	if err := json.Unmarshal([]byte(`{"type":"card","last_four_digits":"4242","iban":"DE89"}`), &combined); err != nil {
+		t.Fatalf("decode combined instrument: %v", err)
+	}
+	if _, ok := combined.AsCard(); !ok {
+		t.Fatalf("expected card variant to be set for combined properties")
+	}
+	if transfer, ok := combined.AsBankTransfer(); !ok || transfer.Iban == nil || *transfer.Iban != "DE89" {
+		t.Fatalf("expected bank transfer variant to be set for combined properties, got %v", transfer)
+	}
+
+	var str Instrument
+	if err := json.Unmarshal([]byte(`"opaque"`), &str); err != nil {
+		t.Fatalf("decode string: %v", err)
+	}
+	if _, ok := str.AsString(); !ok {
+		t.Fatalf("expected string variant to be set")
+	}
+	if _, ok := str.AsCard(); ok {
+		t.Fatalf("expected card variant not to match a string")
+	}
+
+	var unknown Instrument
+	if err := json.Unmarshal([]byte(`{"last_four_digits":"4242"}`), &unknown); err == nil {
+		t.Fatalf("expected error for object without required properties")
+	}
+}
@@ -0,0 +1,92 @@
+package shared
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPaymentMethodDiscriminator(t *testing.T) {
+	var card PaymentMethod
+	if err := json.Unmarshal([]byte(`{"type":"card","last_four_digits":"4242"}`), &card); err != nil {
+		t.Fatalf("decode card: %v", err)
+	}
+	got, ok := card.AsCard()
+	if !ok || got.LastFourDigits == nil || *got.LastFourDigits != "4242" {
+		t.Fatalf("got card variant %+v, want last four digits 4242", got)
+	}
+	if card.BankTransfer != nil {
+		t.Fatalf("expected bank transfer variant to be unset")
+	}
+
+	var transfer PaymentMethod
+	if err := json.Unmarshal([]byte(`{"type":"bank_transfer","iban":"DE89"}`), &transfer); err != nil {
+		t.Fatalf("decode bank transfer: %v", err)
+	}
+	if got, ok := transfer.AsBankTransfer(); !ok || got.Iban == nil || *got.Iban != "DE89" {
+		t.Fatalf("got bank transfer variant %+v, want iban DE89", got)
+	}
+
+	var unknown PaymentMethod
+	if err := json.Unmarshal([]byte(`{"type":"cash"}`), &unknown); err == nil {
+		t.Fatalf("expected error for unknown discriminator")
+	}
+}
+
+func TestOneOfFallback(t *testing.T) {
+	for name, tc := range map[string]struct {
+		data  string
+		check func(GetOneOf200Response) bool
+	}{
+		"string": {`"abc"`, func(v GetOneOf200Response) bool { return v.String != nil && *v.String == "abc" }},
+		"int":    {`42`, func(v GetOneOf200Response) bool { return v.Int != nil && *v.Int == 42 }},
+		"card":   {`{"type":"card"}`, func(v GetOneOf200Response) bool { return v.Card != nil && v.Card.Type == "card" }},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var v GetOneOf200Response
+			if err := json.Unmarshal([]byte(tc.data), &v); err != nil {
+				t.Fatalf("decode %s: %v", tc.data, err)
+			}
+			if !tc.check(v) {
+				t.Fatalf("got %+v, want %s variant", v, name)
+			}
+		})
+	}
+
+	var v GetOneOf200Response
+	if err := json.Unmarshal([]byte(`true`), &v); err == nil {
+		t.Fatalf("expected error for data matching no variant")
+	}
+}
+
+func TestOneOfMarshalJSON(t *testing.T) {
+	iban := "DE89"
+	data, err := json.Marshal(PaymentMethod{BankTransfer: &BankTransfer{Type: "bank_transfer", Iban: &iban}})
+	if err != nil {
+		t.Fatalf("encode payment method: %v", err)
+	}
+	if got, want := string(data), `{"iban":"DE89","type":"bank_transfer"}`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	data, err = json.Marshal(PaymentMethod{})
+	if err != nil {
+		t.Fatalf("encode empty payment method: %v", err)
+	}
+	if got, want := string(data), "null"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestOneOfError(t *testing.T) {
+	message := "invalid"
+	err := &GetPaymentMethod400Response{ErrorVariant: &Error{Code: "bad_request", Message: &message}}
+	if got, want := err.Error(), (&Error{Code: "bad_request", Message: &message}).Error(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	text := "boom"
+	err = &GetPaymentMethod400Response{String: &text}
+	if got, want := err.Error(), `GetPaymentMethod400Response: "boom"`; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	"codegen/client"
+)
+
+// paginationServer serves the pages of cards (cursor), bank transfers (offset),
+// and events (next link).
+func paginationServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	respond := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /cards", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			respond(w, map[string]any{"items": []any{map[string]any{"type": "card", "last_four_digits": "1"}, map[string]any{"type": "card", "last_four_digits": "2"}}, "next_cursor": "next"})
+		case "next":
+			respond(w, map[string]any{"items": []any{map[string]any{"type": "card", "last_four_digits": "3"}}})
+		default:
+			http.Error(w, "unknown cursor", http.StatusBadRequest)
+		}
+	})
+	mux.HandleFunc("GET /bank-transfers", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		items := make([]any, 0)
+		for i := offset; i < min(offset+limit, 5); i++ {
+			items = append(items, map[string]any{"type": "bank_transfer", "iban": strconv.Itoa(i)})
+		}
+		respond(w, map[string]any{"items": items})
+	})
+	mux.HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			respond(w, map[string]any{"events": []any{map[string]any{"id": "b"}}, "next": nil})
+			return
+		}
+		respond(w, map[string]any{"events": []any{map[string]any{"id": "a"}}, "next": "/events?page=2"})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// collect returns the values of the items of the iterator as returned by value.
+func collect[T any](t *testing.T, seq iter.Seq2[T, error], value func(T) string) []string {
+	t.Helper()
+
+	values := make([]string, 0)
+	for item, err := range seq {
+		if err != nil {
+			t.Fatalf("iterate: %v", err)
+		}
+		values = append(values, value(item))
+	}
+	return values
+}
+
+func TestPagination(t *testing.T) {
+	srv := paginationServer(t)
+	c, err := client.NewWithError(client.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	s := NewSharedService(c)
+	ctx := context.Background()
+
+	limit := int32(2)
+	for name, tc := range map[string]struct {
+		values func() []string
+		want   []string
+	}{
+		"cursor": {
+			values: func() []string {
+				return collect(t, s.ListCardsAll(ctx, ListCardsParams{}), func(c Card) string { return *c.LastFourDigits })
+			},
+			want: []string{"1", "2", "3"},
+		},
+		"offset": {
+			values: func() []string {
+				return collect(t, s.ListBankTransfersAll(ctx, ListBankTransfersParams{Limit: &limit}), func(b BankTransfer) string { return *b.Iban })
+			},
+			want: []string{"0", "1", "2", "3", "4"},
+		},
+		"next link": {
+			values: func() []string {
+				return collect(t, s.ListEventsAll(ctx), func(e ListEvents200ResponseEvent) string { return *e.ID })
+			},
+			want: []string{"a", "b"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.values(); !slices.Equal(got, tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPaginationRestart(t *testing.T) {
+	srv := paginationServer(t)
+	c, err := client.NewWithError(client.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	s := NewSharedService(c)
+	ctx := context.Background()
+
+	cards := s.ListCardsAll(ctx, ListCardsParams{})
+	lastFour := func(c Card) string { return *c.LastFourDigits }
+	first := collect(t, cards, lastFour)
+	if second := collect(t, cards, lastFour); !slices.Equal(first, second) {
+		t.Fatalf("expected the iterator to start from the first page again, got %v, want %v", second, first)
+	}
+
+	limit := int32(2)
+	transfers := s.ListBankTransfersPages(ctx, ListBankTransfersParams{Limit: &limit})
+	pages := func(p *ListBankTransfers200Response) string { return strconv.Itoa(len(p.Items)) }
+	first = collect(t, transfers, pages)
+	if second := collect(t, transfers, pages); !slices.Equal(first, second) {
+		t.Fatalf("expected the iterator to start from the first page again, got %v, want %v", second, first)
+	}
+}
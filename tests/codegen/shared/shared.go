@@ -3,24 +3,34 @@
 package shared
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"iter"
+	"maps"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"codegen/client"
 	"codegen/datetime"
+	"codegen/nullable"
+	"codegen/secret"
 )
 
 // AllEnumTypes is a schema definition.
 type AllEnumTypes struct {
-	IntegerEnum AllEnumTypesIntegerEnum `json:"integer_enum"`
+	IntegerEnum AllEnumTypesIntegerEnum `json:"integer_enum" xml:"integer_enum"`
 	// Format: int64
-	IntegerWithFormatEnum *AllEnumTypesIntegerWithFormatEnum `json:"integer_with_format_enum,omitempty"`
-	NumberEnum            AllEnumTypesNumberEnum             `json:"number_enum"`
-	NumberWithFormatEnum  *AllEnumTypesNumberWithFormatEnum  `json:"number_with_format_enum,omitempty"`
-	StringEnum            AllEnumTypesStringEnum             `json:"string_enum"`
+	IntegerWithFormatEnum *AllEnumTypesIntegerWithFormatEnum `json:"integer_with_format_enum,omitempty" xml:"integer_with_format_enum,omitempty"`
+	NumberEnum            AllEnumTypesNumberEnum             `json:"number_enum" xml:"number_enum"`
+	NumberWithFormatEnum  *AllEnumTypesNumberWithFormatEnum  `json:"number_with_format_enum,omitempty" xml:"number_with_format_enum,omitempty"`
+	StringEnum            AllEnumTypesStringEnum             `json:"string_enum" xml:"string_enum"`
 }
 
 // AllEnumTypesIntegerEnum is a schema definition.
@@ -70,137 +80,1736 @@ const (
 // AllStringFormats is a schema definition.
 type AllStringFormats struct {
 	// Format: date
-	Date datetime.Date `json:"date"`
+	Date datetime.Date `json:"date" xml:"date"`
 	// Format: date_time
-	DateTime string `json:"date_time"`
+	DateTime string `json:"date_time" xml:"date_time"`
+	// Format: password
+	Password *secret.Secret `json:"password,omitempty" xml:"password,omitempty"`
 	// Format: time
-	Time datetime.Time `json:"time"`
+	Time datetime.Time `json:"time" xml:"time"`
+}
+
+// AnyOfTypes is a schema definition.
+type AnyOfTypes struct {
+	Instrument           *Instrument           `json:"instrument,omitempty" xml:"instrument,omitempty"`
+	NullableBankTransfer *NullableBankTransfer `json:"nullable_bank_transfer" xml:"nullable_bank_transfer"`
+	NullableCard         *Card                 `json:"nullable_card" xml:"nullable_card"`
+}
+
+// BankTransfer is a schema definition.
+type BankTransfer struct {
+	Iban *string `json:"iban,omitempty" xml:"iban,omitempty"`
+	Type string  `json:"type" xml:"type"`
+}
+
+// Card is a schema definition.
+type Card struct {
+	LastFourDigits *string `json:"last_four_digits,omitempty" xml:"last_four_digits,omitempty"`
+	Type           string  `json:"type" xml:"type"`
+}
+
+// CardList is a schema definition.
+type CardList struct {
+	Items      []Card  `json:"items" xml:"items"`
+	NextCursor *string `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+}
+
+// CardStatus is a schema definition.
+type CardStatus string
+
+const (
+	CardStatusActive  CardStatus = "active"
+	CardStatusExpired CardStatus = "expired"
+)
+
+// Error is a schema definition.
+type Error struct {
+	Code    string  `json:"code" xml:"code"`
+	Message *string `json:"message,omitempty" xml:"message,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("code=%v, message=%v", e.Code, e.Message)
+}
+
+var _ error = (*Error)(nil)
+
+// Event is a schema definition.
+type Event struct {
+	ID   string `json:"id" xml:"id"`
+	Type string `json:"type" xml:"type"`
+}
+
+// Instrument is a schema definition.
+type Instrument struct {
+	Card         *Card
+	BankTransfer *BankTransfer
+	String       *string
+
+	raw json.RawMessage
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. The data are decoded into every
+// variant that they match without unknown fields. If there is no such variant,
+// the data are decoded into every variant whose required properties they contain.
+func (v *Instrument) UnmarshalJSON(data []byte) error {
+	*v = Instrument{raw: append(json.RawMessage(nil), data...)}
+
+	var properties map[string]json.RawMessage
+	_ = json.Unmarshal(data, &properties)
+
+	for _, strict := range []bool{true, false} {
+		if properties["type"] != nil {
+			var variant Card
+			dec := json.NewDecoder(bytes.NewReader(data))
+			if strict {
+				dec.DisallowUnknownFields()
+			}
+			if err := dec.Decode(&variant); err == nil {
+				v.Card = &variant
+			}
+		}
+		if properties["type"] != nil {
+			var variant BankTransfer
+			dec := json.NewDecoder(bytes.NewReader(data))
+			if strict {
+				dec.DisallowUnknownFields()
+			}
+			if err := dec.Decode(&variant); err == nil {
+				v.BankTransfer = &variant
+			}
+		}
+		if strict {
+			var variant string
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&variant); err == nil {
+				v.String = &variant
+			}
+		}
+
+		if v.Card != nil || v.BankTransfer != nil || v.String != nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("decode Instrument: data does not match any variant")
+}
+
+// MarshalJSON implements [json.Marshaler]. If multiple variants are set, their
+// properties are merged into a single object.
+func (v Instrument) MarshalJSON() ([]byte, error) {
+	variants := make([]any, 0, 3)
+	if v.Card != nil {
+		variants = append(variants, v.Card)
+	}
+	if v.BankTransfer != nil {
+		variants = append(variants, v.BankTransfer)
+	}
+	if v.String != nil {
+		variants = append(variants, v.String)
+	}
+
+	switch len(variants) {
+	case 0:
+		if v.raw != nil {
+			return v.raw, nil
+		}
+		return []byte("null"), nil
+	case 1:
+		return json.Marshal(variants[0])
+	}
+
+	merged := make(map[string]json.RawMessage)
+	for _, variant := range variants {
+		data, err := json.Marshal(variant)
+		if err != nil {
+			return nil, err
+		}
+
+		var properties map[string]json.RawMessage
+		if err := json.Unmarshal(data, &properties); err != nil {
+			return nil, fmt.Errorf("encode Instrument: only object variants can be merged: %w", err)
+		}
+
+		maps.Copy(merged, properties)
+	}
+
+	return json.Marshal(merged)
+}
+
+// Raw returns the raw JSON that [Instrument] was decoded from.
+func (v *Instrument) Raw() json.RawMessage {
+	return v.raw
+}
+
+// AsCard returns the Card variant of [Instrument], if the data matched it.
+func (v *Instrument) AsCard() (*Card, bool) {
+	return v.Card, v.Card != nil
+}
+
+// AsBankTransfer returns the BankTransfer variant of [Instrument], if the data matched it.
+func (v *Instrument) AsBankTransfer() (*BankTransfer, bool) {
+	return v.BankTransfer, v.BankTransfer != nil
+}
+
+// AsString returns the String variant of [Instrument], if the data matched it.
+func (v *Instrument) AsString() (*string, bool) {
+	return v.String, v.String != nil
+}
+
+// NullableBankTransfer is a schema definition.
+type NullableBankTransfer = BankTransfer
+
+// NullableFields is a schema definition.
+type NullableFields struct {
+	Card             nullable.Nullable[Card]     `json:"card,omitzero" xml:"card,omitempty"`
+	ExpiryMonth      nullable.Nullable[int]      `json:"expiry_month,omitzero" xml:"expiry_month,omitempty"`
+	Nickname         nullable.Nullable[string]   `json:"nickname,omitzero" xml:"nickname,omitempty"`
+	RequiredNullable *string                     `json:"required_nullable" xml:"required_nullable"`
+	Tags             nullable.Nullable[[]string] `json:"tags,omitzero" xml:"tags,omitempty"`
+}
+
+// PartnerOrder is a schema definition.
+type PartnerOrder struct {
+	Amount    float64                   `json:"amount" xml:"total"`
+	ID        string                    `json:"id" xml:"id,attr"`
+	Lines     []PartnerOrderLine        `json:"lines" xml:"lines>line"`
+	Note      nullable.Nullable[string] `json:"note,omitzero" xml:"note,omitempty"`
+	Reference *string                   `json:"reference,omitempty" xml:"https://codegen.example.com/references reference,omitempty"`
+	Tags      []string                  `json:"tags,omitempty" xml:"tag,omitempty"`
+}
+
+// PartnerOrderLine is a schema definition.
+type PartnerOrderLine struct {
+	Quantity *int   `json:"quantity,omitempty" xml:"quantity,attr,omitempty"`
+	Sku      string `json:"sku" xml:"sku"`
+}
+
+// PaymentInstrument is a schema definition.
+type PaymentInstrument struct {
+	CreatedAt *time.Time `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	ID        string     `json:"id" xml:"id"`
+}
+
+// PaymentMethod is a schema definition.
+type PaymentMethod struct {
+	Card         *Card
+	BankTransfer *BankTransfer
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (v *PaymentMethod) UnmarshalJSON(data []byte) error {
+	var discriminator struct {
+		Value string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return fmt.Errorf("decode PaymentMethod discriminator: %w", err)
+	}
+
+	switch discriminator.Value {
+	case "card":
+		var variant Card
+		if err := json.Unmarshal(data, &variant); err != nil {
+			return fmt.Errorf("decode PaymentMethod as Card: %w", err)
+		}
+		*v = PaymentMethod{Card: &variant}
+		return nil
+	case "bank_transfer":
+		var variant BankTransfer
+		if err := json.Unmarshal(data, &variant); err != nil {
+			return fmt.Errorf("decode PaymentMethod as BankTransfer: %w", err)
+		}
+		*v = PaymentMethod{BankTransfer: &variant}
+		return nil
+	}
+
+	return fmt.Errorf("decode PaymentMethod: unknown type %q", discriminator.Value)
+}
+
+// MarshalJSON implements [json.Marshaler].
+func (v PaymentMethod) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Card != nil:
+		return json.Marshal(v.Card)
+	case v.BankTransfer != nil:
+		return json.Marshal(v.BankTransfer)
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// AsCard returns the Card variant of [PaymentMethod], if set.
+func (v *PaymentMethod) AsCard() (*Card, bool) {
+	return v.Card, v.Card != nil
+}
+
+// AsBankTransfer returns the BankTransfer variant of [PaymentMethod], if set.
+func (v *PaymentMethod) AsBankTransfer() (*BankTransfer, bool) {
+	return v.BankTransfer, v.BankTransfer != nil
+}
+
+// Report is a schema definition.
+type Report struct {
+	ID    string  `json:"id" xml:"id"`
+	Total float64 `json:"total" xml:"total"`
+}
+
+// ReportRequest is a schema definition.
+type ReportRequest struct {
+	Currency *string `json:"currency,omitempty" xml:"currency,omitempty"`
+	// Format: date
+	From datetime.Date `json:"from" xml:"from"`
+	// Format: date
+	To datetime.Date `json:"to" xml:"to"`
+}
+
+// StoredCard is a schema definition.
+type StoredCard struct {
+	PaymentInstrument
+	ExpiryMonth int                           `json:"expiry_month" xml:"expiry_month"`
+	Status      nullable.Nullable[CardStatus] `json:"status,omitzero" xml:"status,omitempty"`
+}
+
+// ValidationProblem: Problem details with validation errors.
+type ValidationProblem struct {
+	Errors []string `json:"errors,omitempty" xml:"errors,omitempty"`
+	Status *int     `json:"status,omitempty" xml:"status,omitempty"`
+	Title  string   `json:"title" xml:"title"`
+	Type   *string  `json:"type,omitempty" xml:"type,omitempty"`
+}
+
+func (e *ValidationProblem) Error() string {
+	return fmt.Sprintf("errors=%v, status=%v, title=%v, type=%v", e.Errors, e.Status, e.Title, e.Type)
+}
+
+var _ error = (*ValidationProblem)(nil)
+
+// CreateTokenBody is a schema definition.
+type CreateTokenBody struct {
+	Audiences []string
+	ExpiresIn *int
+	GrantType CreateTokenBodyGrantType
+	Metadata  *CreateTokenBodyMetadata
+	Scope     []string
+}
+
+// CreateTokenBodyGrantType is a schema definition.
+type CreateTokenBodyGrantType string
+
+const (
+	CreateTokenBodyGrantTypeClientCredentials CreateTokenBodyGrantType = "client_credentials"
+	CreateTokenBodyGrantTypeRefreshToken      CreateTokenBodyGrantType = "refresh_token"
+)
+
+// CreateTokenBodyMetadata is a schema definition.
+type CreateTokenBodyMetadata struct {
+	Device  *string `json:"device,omitempty" xml:"device,omitempty"`
+	Version *int    `json:"version,omitempty" xml:"version,omitempty"`
+}
+
+// FormValues converts [CreateTokenBody] into [url.Values] of form-urlencoded body.
+func (p *CreateTokenBody) FormValues() url.Values {
+	f := make(url.Values)
+
+	for _, v := range p.Audiences {
+		f.Add("audiences", v)
+	}
+
+	if p.ExpiresIn != nil {
+		f.Add("expires_in", strconv.Itoa(*p.ExpiresIn))
+	}
+
+	f.Add("grant_type", string(p.GrantType))
+
+	if p.Metadata != nil {
+		if p.Metadata.Device != nil {
+			f.Add("metadata[device]", *p.Metadata.Device)
+		}
+		if p.Metadata.Version != nil {
+			f.Add("metadata[version]", strconv.Itoa(*p.Metadata.Version))
+		}
+	}
+
+	if len(p.Scope) != 0 {
+		values := make([]string, 0, len(p.Scope))
+		for _, v := range p.Scope {
+			values = append(values, v)
+		}
+		f.Add("scope", strings.Join(values, " "))
+	}
+
+	return f
+}
+
+// CreateReportBody is a schema definition.
+type CreateReportBody struct {
+	Currency *string `json:"currency,omitempty" xml:"currency,omitempty"`
+	// Format: date
+	From datetime.Date `json:"from" xml:"from"`
+	// Format: date
+	To datetime.Date `json:"to" xml:"to"`
+}
+
+// FormValues converts [CreateReportBody] into [url.Values] of form-urlencoded body.
+func (p *CreateReportBody) FormValues() url.Values {
+	f := make(url.Values)
+
+	if p.Currency != nil {
+		f.Add("currency", *p.Currency)
+	}
+
+	f.Add("from", p.From.String())
+
+	f.Add("to", p.To.String())
+
+	return f
+}
+
+// UploadReceiptBody is a schema definition.
+type UploadReceiptBody struct {
+	Amount      *int
+	Attachments []client.File
+	Card        *Card
+	Description *string
+	File        client.File
+	Notes       *string
+	Status      *CardStatus
+	Tags        []string
+}
+
+// WriteMultipart writes [UploadReceiptBody] as parts of multipart/form-data body.
+func (b *UploadReceiptBody) WriteMultipart(w *multipart.Writer) error {
+	if b.Amount != nil {
+		if err := w.WriteField("amount", strconv.Itoa(*b.Amount)); err != nil {
+			return fmt.Errorf("write amount: %w", err)
+		}
+	}
+
+	for _, v := range b.Attachments {
+		if err := client.WriteMultipartFile(w, "attachments", v, ""); err != nil {
+			return fmt.Errorf("write attachments: %w", err)
+		}
+	}
+
+	if b.Card != nil {
+		if err := client.WriteMultipartJSON(w, "card", *b.Card, ""); err != nil {
+			return fmt.Errorf("write card: %w", err)
+		}
+	}
+
+	if b.Description != nil {
+		if err := w.WriteField("description", *b.Description); err != nil {
+			return fmt.Errorf("write description: %w", err)
+		}
+	}
+
+	if err := client.WriteMultipartFile(w, "file", b.File, "image/png"); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+
+	if b.Notes != nil {
+		if err := client.WriteMultipartValue(w, "notes", *b.Notes, "text/markdown"); err != nil {
+			return fmt.Errorf("write notes: %w", err)
+		}
+	}
+
+	if b.Status != nil {
+		if err := w.WriteField("status", string(*b.Status)); err != nil {
+			return fmt.Errorf("write status: %w", err)
+		}
+	}
+
+	for _, v := range b.Tags {
+		if err := w.WriteField("tags", v); err != nil {
+			return fmt.Errorf("write tags: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CreatePartnerOrderBody is a schema definition.
+type CreatePartnerOrderBody struct {
+	Amount    float64                   `json:"amount" xml:"total"`
+	ID        string                    `json:"id" xml:"id,attr"`
+	Lines     []PartnerOrderLine        `json:"lines" xml:"lines>line"`
+	Note      nullable.Nullable[string] `json:"note,omitzero" xml:"note,omitempty"`
+	Reference *string                   `json:"reference,omitempty" xml:"https://codegen.example.com/references reference,omitempty"`
+	Tags      []string                  `json:"tags,omitempty" xml:"tag,omitempty"`
+}
+
+// MarshalXML encodes [CreatePartnerOrderBody] as XML element "order".
+func (b CreatePartnerOrderBody) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type body CreatePartnerOrderBody
+	start.Name = xml.Name{Space: "https://codegen.example.com/orders", Local: "order"}
+	return e.EncodeElement(body(b), start)
+}
+
+// UpdateNullableBody is a schema definition.
+type UpdateNullableBody struct {
+	Card             nullable.Nullable[Card]     `json:"card,omitzero" xml:"card,omitempty"`
+	ExpiryMonth      nullable.Nullable[int]      `json:"expiry_month,omitzero" xml:"expiry_month,omitempty"`
+	Nickname         nullable.Nullable[string]   `json:"nickname,omitzero" xml:"nickname,omitempty"`
+	RequiredNullable *string                     `json:"required_nullable" xml:"required_nullable"`
+	Tags             nullable.Nullable[[]string] `json:"tags,omitzero" xml:"tags,omitempty"`
 }
 
 // GetDeprecatedBody is a schema definition.
 type GetDeprecatedBody struct {
 	// Deprecated: Use other - non-deprecated - field instead.
-	Param *string `json:"param,omitempty"`
+	Param *string `json:"param,omitempty" xml:"param,omitempty"`
+}
+
+// GetAllStringFormatsParams: query parameters for getAllStringFormats
+type GetAllStringFormatsParams struct {
+	Date     *datetime.Date
+	Password *secret.Secret
+	Time     *datetime.Time
+}
+
+// QueryValues converts [GetAllStringFormatsParams] into [url.Values].
+func (p *GetAllStringFormatsParams) QueryValues() url.Values {
+	q := make(url.Values)
+
+	if p.Date != nil {
+		q.Add("date", p.Date.String())
+	}
+
+	if p.Password != nil {
+		q.Add("password", p.Password.Value())
+	}
+
+	if p.Time != nil {
+		q.Add("time", p.Time.String())
+	}
+
+	return q
+}
+
+// ListWithQueryStylesFilter is a schema definition.
+type ListWithQueryStylesFilter struct {
+	Amount       *int       `json:"amount,omitempty" xml:"amount,omitempty"`
+	CreatedAfter *time.Time `json:"created_after,omitempty" xml:"created_after,omitempty"`
+	Currencies   []string   `json:"currencies,omitempty" xml:"currencies,omitempty"`
+	Status       CardStatus `json:"status" xml:"status"`
+}
+
+// ListWithQueryStylesRange is a schema definition.
+type ListWithQueryStylesRange struct {
+	From *int `json:"from,omitempty" xml:"from,omitempty"`
+	To   *int `json:"to,omitempty" xml:"to,omitempty"`
+}
+
+// ListWithQueryStylesParams: query parameters for listWithQueryStyles
+type ListWithQueryStylesParams struct {
+	Codes    []string
+	Filter   *ListWithQueryStylesFilter
+	Ids      []int
+	Range    *ListWithQueryStylesRange
+	Statuses []CardStatus
+	Tags     []string
+}
+
+// QueryValues converts [ListWithQueryStylesParams] into [url.Values].
+func (p *ListWithQueryStylesParams) QueryValues() url.Values {
+	q := make(url.Values)
+
+	if len(p.Codes) != 0 {
+		values := make([]string, 0, len(p.Codes))
+		for _, v := range p.Codes {
+			values = append(values, v)
+		}
+		q.Add("codes", strings.Join(values, " "))
+	}
+
+	if p.Filter != nil {
+		if p.Filter.Amount != nil {
+			q.Add("filter[amount]", strconv.Itoa(*p.Filter.Amount))
+		}
+		if p.Filter.CreatedAfter != nil {
+			q.Add("filter[created_after]", p.Filter.CreatedAfter.Format(time.RFC3339))
+		}
+		for _, v := range p.Filter.Currencies {
+			q.Add("filter[currencies]", v)
+		}
+		q.Add("filter[status]", string(p.Filter.Status))
+	}
+
+	if len(p.Ids) != 0 {
+		values := make([]string, 0, len(p.Ids))
+		for _, v := range p.Ids {
+			values = append(values, strconv.Itoa(v))
+		}
+		q.Add("ids", strings.Join(values, ","))
+	}
+
+	if p.Range != nil {
+		values := make([]string, 0)
+		if p.Range.From != nil {
+			values = append(values, "from", strconv.Itoa(*p.Range.From))
+		}
+		if p.Range.To != nil {
+			values = append(values, "to", strconv.Itoa(*p.Range.To))
+		}
+		q.Add("range", strings.Join(values, ","))
+	}
+
+	for _, v := range p.Statuses {
+		q.Add("statuses", string(v))
+	}
+
+	if len(p.Tags) != 0 {
+		values := make([]string, 0, len(p.Tags))
+		for _, v := range p.Tags {
+			values = append(values, v)
+		}
+		q.Add("tags", strings.Join(values, "|"))
+	}
+
+	return q
+}
+
+// CreateWithHeadersParams: parameters for createWithHeaders
+type CreateWithHeadersParams struct {
+	// If not set, a random key is generated by the client.
+	IdempotencyKey *string
+	XMerchantCodes []string
+	XRetryCount    *int
+	DryRun         *bool
+	Session        *string
+}
+
+// QueryValues converts [CreateWithHeadersParams] into [url.Values].
+func (p *CreateWithHeadersParams) QueryValues() url.Values {
+	q := make(url.Values)
+
+	if p.DryRun != nil {
+		q.Add("dry_run", strconv.FormatBool(*p.DryRun))
+	}
+
+	return q
+}
+
+// Headers converts header parameters of [CreateWithHeadersParams] into [http.Header].
+func (p *CreateWithHeadersParams) Headers() http.Header {
+	h := make(http.Header)
+
+	if p.IdempotencyKey != nil {
+		h.Set("Idempotency-Key", *p.IdempotencyKey)
+	}
+
+	if len(p.XMerchantCodes) != 0 {
+		values := make([]string, 0, len(p.XMerchantCodes))
+		for _, v := range p.XMerchantCodes {
+			values = append(values, v)
+		}
+		h.Set("X-Merchant-Codes", strings.Join(values, ","))
+	}
+
+	if p.XRetryCount != nil {
+		h.Set("X-Retry-Count", strconv.Itoa(*p.XRetryCount))
+	}
+
+	return h
+}
+
+// Cookies converts cookie parameters of [CreateWithHeadersParams] into [http.Cookie]s.
+func (p *CreateWithHeadersParams) Cookies() []*http.Cookie {
+	cookies := make([]*http.Cookie, 0)
+
+	if p.Session != nil {
+		cookies = append(cookies, &http.Cookie{Name: "session", Value: *p.Session})
+	}
+
+	return cookies
+}
+
+// GetDeprecatedParams: query parameters for getDeprecated
+type GetDeprecatedParams struct {
+	Param *string
+}
+
+// QueryValues converts [GetDeprecatedParams] into [url.Values].
+func (p *GetDeprecatedParams) QueryValues() url.Values {
+	q := make(url.Values)
+
+	if p.Param != nil {
+		q.Add("param", *p.Param)
+	}
+
+	return q
+}
+
+// ListCardsParams: query parameters for listCards
+type ListCardsParams struct {
+	Cursor *string
+	Limit  *int
+}
+
+// QueryValues converts [ListCardsParams] into [url.Values].
+func (p *ListCardsParams) QueryValues() url.Values {
+	q := make(url.Values)
+
+	if p.Cursor != nil {
+		q.Add("cursor", *p.Cursor)
+	}
+
+	if p.Limit != nil {
+		q.Add("limit", strconv.Itoa(*p.Limit))
+	}
+
+	return q
+}
+
+// ListBankTransfersParams: query parameters for listBankTransfers
+type ListBankTransfersParams struct {
+	Limit  *int32
+	Offset *int
+}
+
+// QueryValues converts [ListBankTransfersParams] into [url.Values].
+func (p *ListBankTransfersParams) QueryValues() url.Values {
+	q := make(url.Values)
+
+	if p.Limit != nil {
+		q.Add("limit", strconv.FormatInt(int64(*p.Limit), 10))
+	}
+
+	if p.Offset != nil {
+		q.Add("offset", strconv.Itoa(*p.Offset))
+	}
+
+	return q
+}
+
+// GetAllOfInstrument is a schema definition.
+type GetAllOfInstrument struct {
+	Card
+	PaymentInstrument
+}
+
+// GetAllOfParams: query parameters for getAllOf
+type GetAllOfParams struct {
+	Instrument *GetAllOfInstrument
+	Status     *CardStatus
+}
+
+// QueryValues converts [GetAllOfParams] into [url.Values].
+func (p *GetAllOfParams) QueryValues() url.Values {
+	q := make(url.Values)
+
+	if p.Instrument != nil {
+		if p.Instrument.CreatedAt != nil {
+			q.Add("created_at", p.Instrument.CreatedAt.Format(time.RFC3339))
+		}
+		q.Add("id", p.Instrument.ID)
+		if p.Instrument.LastFourDigits != nil {
+			q.Add("last_four_digits", *p.Instrument.LastFourDigits)
+		}
+		q.Add("type", p.Instrument.Type)
+	}
+
+	if p.Status != nil {
+		q.Add("status", string(*p.Status))
+	}
+
+	return q
+}
+
+// StreamTransactionsItem is a schema definition.
+type StreamTransactionsItem struct {
+	Amount float64 `json:"amount" xml:"amount"`
+	ID     string  `json:"id" xml:"id"`
+}
+
+// GetPaymentMethod400Response is a schema definition.
+type GetPaymentMethod400Response struct {
+	ErrorVariant *Error
+	String       *string
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (v *GetPaymentMethod400Response) UnmarshalJSON(data []byte) error {
+	{
+		var variant Error
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&variant); err == nil {
+			*v = GetPaymentMethod400Response{ErrorVariant: &variant}
+			return nil
+		}
+	}
+
+	{
+		var variant string
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&variant); err == nil {
+			*v = GetPaymentMethod400Response{String: &variant}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("decode GetPaymentMethod400Response: data does not match any variant")
+}
+
+// MarshalJSON implements [json.Marshaler].
+func (v GetPaymentMethod400Response) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.ErrorVariant != nil:
+		return json.Marshal(v.ErrorVariant)
+	case v.String != nil:
+		return json.Marshal(v.String)
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// AsErrorVariant returns the ErrorVariant variant of [GetPaymentMethod400Response], if set.
+func (v *GetPaymentMethod400Response) AsErrorVariant() (*Error, bool) {
+	return v.ErrorVariant, v.ErrorVariant != nil
+}
+
+// AsString returns the String variant of [GetPaymentMethod400Response], if set.
+func (v *GetPaymentMethod400Response) AsString() (*string, bool) {
+	return v.String, v.String != nil
+}
+
+func (e *GetPaymentMethod400Response) Error() string {
+	switch {
+	case e.ErrorVariant != nil:
+		if err, ok := any(e.ErrorVariant).(error); ok {
+			return err.Error()
+		}
+		data, _ := json.Marshal(e.ErrorVariant)
+		return fmt.Sprintf("GetPaymentMethod400Response: %s", data)
+	case e.String != nil:
+		if err, ok := any(e.String).(error); ok {
+			return err.Error()
+		}
+		data, _ := json.Marshal(e.String)
+		return fmt.Sprintf("GetPaymentMethod400Response: %s", data)
+	default:
+		return "GetPaymentMethod400Response"
+	}
+}
+
+var _ error = (*GetPaymentMethod400Response)(nil)
+
+// GetOneOf200Response is a schema definition.
+type GetOneOf200Response struct {
+	String *string
+	Int    *int
+	Card   *Card
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (v *GetOneOf200Response) UnmarshalJSON(data []byte) error {
+	{
+		var variant string
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&variant); err == nil {
+			*v = GetOneOf200Response{String: &variant}
+			return nil
+		}
+	}
+
+	{
+		var variant int
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&variant); err == nil {
+			*v = GetOneOf200Response{Int: &variant}
+			return nil
+		}
+	}
+
+	{
+		var variant Card
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&variant); err == nil {
+			*v = GetOneOf200Response{Card: &variant}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("decode GetOneOf200Response: data does not match any variant")
+}
+
+// MarshalJSON implements [json.Marshaler].
+func (v GetOneOf200Response) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.String != nil:
+		return json.Marshal(v.String)
+	case v.Int != nil:
+		return json.Marshal(v.Int)
+	case v.Card != nil:
+		return json.Marshal(v.Card)
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// AsString returns the String variant of [GetOneOf200Response], if set.
+func (v *GetOneOf200Response) AsString() (*string, bool) {
+	return v.String, v.String != nil
+}
+
+// AsInt returns the Int variant of [GetOneOf200Response], if set.
+func (v *GetOneOf200Response) AsInt() (*int, bool) {
+	return v.Int, v.Int != nil
+}
+
+// AsCard returns the Card variant of [GetOneOf200Response], if set.
+func (v *GetOneOf200Response) AsCard() (*Card, bool) {
+	return v.Card, v.Card != nil
+}
+
+// ListEvents200Response is a schema definition.
+type ListEvents200Response struct {
+	Events []ListEvents200ResponseEvent `json:"events,omitempty" xml:"events,omitempty"`
+	Next   nullable.Nullable[string]    `json:"next,omitzero" xml:"next,omitempty"`
+}
+
+// ListEvents200ResponseEvent is a schema definition.
+type ListEvents200ResponseEvent struct {
+	ID *string `json:"id,omitempty" xml:"id,omitempty"`
+}
+
+// GetDeprecated200Response is a schema definition.
+type GetDeprecated200Response struct {
+	// Deprecated: Use other - non-deprecated - field instead.
+	Param *string `json:"param,omitempty" xml:"param,omitempty"`
+}
+
+// ListBankTransfers200Response is a schema definition.
+type ListBankTransfers200Response struct {
+	Items []BankTransfer `json:"items" xml:"items"`
+}
+
+// CreateReportResponseMeta is the metadata of the response to createReport, see [client.WithResponse].
+type CreateReportResponseMeta struct {
+	client.Response
+	// URL of the created report.
+	Location *string
+	// Number of requests remaining in the current rate limit window.
+	XRateLimitRemaining *int
+	// Time the report expires at.
+	XReportExpiresAt *time.Time
+	// Formats the report can be downloaded in.
+	XReportFormats []string
+}
+
+// SetResponse implements [client.ResponseMetadata].
+func (m *CreateReportResponseMeta) SetResponse(resp *http.Response) {
+	m.Response.SetResponse(resp)
+	m.Location = client.HeaderValue[string](resp.Header, "Location")
+	m.XRateLimitRemaining = client.HeaderValue[int](resp.Header, "X-RateLimit-Remaining")
+	m.XReportExpiresAt = client.HeaderValue[time.Time](resp.Header, "X-Report-Expires-At")
+	m.XReportFormats = client.HeaderValues[string](resp.Header, "X-Report-Formats")
+}
+
+type SharedService struct {
+	c *client.Client
+}
+
+func NewSharedService(c *client.Client) *SharedService {
+	return &SharedService{c: c}
+}
+
+// GetVendorJson: Get vendor JSON
+func (s *SharedService) GetVendorJson(ctx context.Context, opts ...client.RequestOption) (*Card, error) {
+	path := fmt.Sprintf("/vendor-json")
+
+	opts = append([]client.RequestOption{client.WithAccept("application/vnd.codegen.card+json", "application/problem+json"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var v Card
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	case http.StatusBadRequest:
+		var apiErr ValidationProblem
+		return nil, client.NewAPIError(resp, "Request is invalid.", &apiErr)
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// StreamTransactions: Export transactions as NDJSON
+func (s *SharedService) StreamTransactions(ctx context.Context, opts ...client.RequestOption) (*client.Stream[StreamTransactionsItem], error) {
+	path := fmt.Sprintf("/transactions/export")
+
+	opts = append([]client.RequestOption{client.WithAccept("application/x-ndjson", "application/json"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// the stream is closed by the caller
+		return client.NewStream[StreamTransactionsItem](resp), nil
+	case http.StatusNotFound:
+		defer resp.Body.Close()
+		var apiErr Error
+		return nil, client.NewAPIError(resp, "Merchant not found.", &apiErr)
+	default:
+		defer resp.Body.Close()
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// CreateToken: Create token
+func (s *SharedService) CreateToken(ctx context.Context, body CreateTokenBody, opts ...client.RequestOption) error {
+	path := fmt.Sprintf("/tokens")
+
+	opts = append([]client.RequestOption{client.WithFormBody(body.FormValues())}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodPost, path, opts...)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	default:
+		return client.NewAPIError(resp, "", nil)
+	}
+}
+
+// GetAllStringFormats: Get all string formats
+func (s *SharedService) GetAllStringFormats(ctx context.Context, params GetAllStringFormatsParams, opts ...client.RequestOption) (*AllStringFormats, error) {
+	path := fmt.Sprintf("/string-formats")
+
+	opts = append([]client.RequestOption{client.WithAccept("application/json"), client.WithQueryValues(params.QueryValues()), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var v AllStringFormats
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// GetStatus: Get status
+func (s *SharedService) GetStatus(ctx context.Context, opts ...client.RequestOption) (*string, error) {
+	path := fmt.Sprintf("/status")
+
+	opts = append([]client.RequestOption{client.WithAccept("text/plain"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		v, err := client.ReadText(resp)
+		if err != nil {
+			return nil, fmt.Errorf("read response: %w", err)
+		}
+
+		return &v, nil
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// CreateReport: Create report
+//
+// The headers of the response are available through [CreateReportResponseMeta], see [client.WithResponse].
+func (s *SharedService) CreateReport(ctx context.Context, body CreateReportBody, opts ...client.RequestOption) (*Report, error) {
+	path := fmt.Sprintf("/reports")
+
+	opts = append([]client.RequestOption{client.WithBody(&body, "application/json", "application/vnd.codegen.report+json", "application/x-www-form-urlencoded"), client.WithAccept("application/json", "application/problem+json"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodPost, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var v Report
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	case http.StatusBadRequest:
+		var apiErr ValidationProblem
+		return nil, client.NewAPIError(resp, "Invalid report request.", &apiErr)
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
 }
 
-// GetAllStringFormatsParams: query parameters for getAllStringFormats
-type GetAllStringFormatsParams struct {
-	Date *datetime.Date
-	Time *datetime.Time
+// CreateReportCSV: Create report, requesting the response as text/csv.
+//
+// The headers of the response are available through [CreateReportResponseMeta], see [client.WithResponse].
+func (s *SharedService) CreateReportCSV(ctx context.Context, body CreateReportBody, opts ...client.RequestOption) (*client.Binary, error) {
+	path := fmt.Sprintf("/reports")
+
+	opts = append([]client.RequestOption{client.WithBody(&body, "application/json", "application/vnd.codegen.report+json", "application/x-www-form-urlencoded"), client.WithAccept("text/csv", "application/problem+json"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodPost, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		// the body is closed by the caller
+		return client.NewBinary(resp), nil
+	case http.StatusBadRequest:
+		defer resp.Body.Close()
+		var apiErr ValidationProblem
+		return nil, client.NewAPIError(resp, "Invalid report request.", &apiErr)
+	default:
+		defer resp.Body.Close()
+		return nil, client.NewAPIError(resp, "", nil)
+	}
 }
 
-// QueryValues converts [GetAllStringFormatsParams] into [url.Values].
-func (p *GetAllStringFormatsParams) QueryValues() url.Values {
-	q := make(url.Values)
+// UploadReceipt: Upload receipt
+func (s *SharedService) UploadReceipt(ctx context.Context, body UploadReceiptBody, opts ...client.RequestOption) error {
+	path := fmt.Sprintf("/receipts")
 
-	if p.Date != nil {
-		q.Set("date", p.Date.String())
+	opts = append([]client.RequestOption{client.WithMultipartBody(&body), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodPost, path, opts...)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
 	}
+	defer resp.Body.Close()
 
-	if p.Time != nil {
-		q.Set("time", p.Time.String())
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	default:
+		return client.NewAPIError(resp, "", nil)
+	}
+}
+
+// ListWithQueryStyles: List with query parameters serialized using different styles
+func (s *SharedService) ListWithQueryStyles(ctx context.Context, params ListWithQueryStylesParams, opts ...client.RequestOption) error {
+	path := fmt.Sprintf("/query-styles")
+
+	opts = append([]client.RequestOption{client.WithOperationServer(client.Server{URL: "https://{region}.search.example.com", Variables: map[string]client.ServerVariable{"region": {Default: "eu"}}}), client.WithQueryValues(params.QueryValues()), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
 	}
+	defer resp.Body.Close()
 
-	return q
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	default:
+		return client.NewAPIError(resp, "", nil)
+	}
 }
 
-// GetDeprecatedParams: query parameters for getDeprecated
-type GetDeprecatedParams struct {
-	Param *string
+// GetPaymentMethod: Get payment method
+func (s *SharedService) GetPaymentMethod(ctx context.Context, opts ...client.RequestOption) (*PaymentMethod, error) {
+	path := fmt.Sprintf("/payment-methods")
+
+	opts = append([]client.RequestOption{client.WithAccept("application/json", "application/problem+json"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var v PaymentMethod
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	case http.StatusBadRequest:
+		var apiErr GetPaymentMethod400Response
+		return nil, client.NewAPIError(resp, "Payment method request is invalid.", &apiErr)
+	case http.StatusNotFound:
+		var apiErr Error
+		return nil, client.NewAPIError(resp, "Payment method not found.", &apiErr)
+	case http.StatusConflict:
+		return nil, client.NewAPIError(resp, "Payment method is being updated.", nil)
+	case http.StatusUnprocessableEntity:
+		var apiErr client.Problem
+		return nil, client.NewAPIError(resp, "Payment method is invalid.", &apiErr)
+	case http.StatusInternalServerError:
+		var apiErr client.Problem
+		return nil, client.NewAPIError(resp, "Internal server error.", &apiErr)
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
 }
 
-// QueryValues converts [GetDeprecatedParams] into [url.Values].
-func (p *GetDeprecatedParams) QueryValues() url.Values {
-	q := make(url.Values)
+// CreatePartnerOrder: Create partner order
+func (s *SharedService) CreatePartnerOrder(ctx context.Context, body CreatePartnerOrderBody, opts ...client.RequestOption) (*PartnerOrder, error) {
+	path := fmt.Sprintf("/partner-orders")
 
-	if p.Param != nil {
-		q.Set("param", *p.Param)
+	opts = append([]client.RequestOption{client.WithXMLBody(body), client.WithAccept("application/xml"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodPost, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
 	}
+	defer resp.Body.Close()
 
-	return q
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var v PartnerOrder
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	case http.StatusBadRequest:
+		var apiErr Error
+		return nil, client.NewAPIError(resp, "Invalid partner order.", &apiErr)
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
 }
 
-// GetDeprecated200Response is a schema definition.
-type GetDeprecated200Response struct {
-	// Deprecated: Use other - non-deprecated - field instead.
-	Param *string `json:"param,omitempty"`
+// GetOneOf: Get oneOf without discriminator
+func (s *SharedService) GetOneOf(ctx context.Context, opts ...client.RequestOption) (*GetOneOf200Response, error) {
+	path := fmt.Sprintf("/one-of")
+
+	opts = append([]client.RequestOption{client.WithAccept("application/json")}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var v GetOneOf200Response
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
 }
 
-type SharedService struct {
-	c *client.Client
+// UpdateNullable: Update nullable fields
+func (s *SharedService) UpdateNullable(ctx context.Context, body UpdateNullableBody, opts ...client.RequestOption) error {
+	path := fmt.Sprintf("/nullable")
+
+	opts = append([]client.RequestOption{client.WithJSONBody(body), client.WithIdempotency(), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodPatch, path, opts...)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	default:
+		return client.NewAPIError(resp, "", nil)
+	}
 }
 
-func NewSharedService(c *client.Client) *SharedService {
-	return &SharedService{c: c}
+// CreateWithHeaders: Create with header and cookie parameters
+func (s *SharedService) CreateWithHeaders(ctx context.Context, params CreateWithHeadersParams, opts ...client.RequestOption) error {
+	path := fmt.Sprintf("/header-params")
+
+	opts = append([]client.RequestOption{client.WithQueryValues(params.QueryValues()), client.WithHeaders(params.Headers()), client.WithCookies(params.Cookies()), client.WithIdempotency(), client.WithSecurity(client.SecurityRequirement{"basicAuth", "merchantKey"}, client.SecurityRequirement{"queryKey"}, client.SecurityRequirement{"sessionKey"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodPost, path, opts...)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	default:
+		return client.NewAPIError(resp, "", nil)
+	}
 }
 
-// GetAllStringFormats: Get all string formats
-func (s *SharedService) GetAllStringFormats(ctx context.Context, params GetAllStringFormatsParams) (*AllStringFormats, error) {
-	path := fmt.Sprintf("/string-formats")
+// ExportTransactions: Export transactions
+func (s *SharedService) ExportTransactions(ctx context.Context, opts ...client.RequestOption) (*client.Binary, error) {
+	path := fmt.Sprintf("/exports")
+
+	opts = append([]client.RequestOption{client.WithAccept("text/csv"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// the body is closed by the caller
+		return client.NewBinary(resp), nil
+	default:
+		defer resp.Body.Close()
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// StreamEvents: Stream events
+func (s *SharedService) StreamEvents(ctx context.Context, opts ...client.RequestOption) (*client.Stream[Event], error) {
+	path := fmt.Sprintf("/events/stream")
+
+	opts = append([]client.RequestOption{client.WithAccept("text/event-stream"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// the stream is closed by the caller
+		return client.NewEventStream[Event](resp, func(reconnectOpts ...client.RequestOption) (*http.Response, error) {
+			return s.c.Call(ctx, http.MethodGet, path, append(opts[:len(opts):len(opts)], reconnectOpts...)...)
+		}), nil
+	default:
+		defer resp.Body.Close()
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// ListEvents: List events
+func (s *SharedService) ListEvents(ctx context.Context, opts ...client.RequestOption) (*ListEvents200Response, error) {
+	path := fmt.Sprintf("/events")
 
-	resp, err := s.c.Call(ctx, http.MethodGet, path, client.WithQueryValues(params.QueryValues()))
+	opts = append([]client.RequestOption{client.WithAccept("application/json"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("error building request: %v", err)
+		return nil, fmt.Errorf("error building request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusOK:
-		var v AllStringFormats
-		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
-			return nil, fmt.Errorf("decode response: %s", err.Error())
+		var v ListEvents200Response
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
 		}
 
 		return &v, nil
 	default:
-		return nil, fmt.Errorf("unexpected response %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// ListEventsPages returns an iterator over the pages of [SharedService.ListEvents],
+// requesting the next page when the previous one is consumed.
+func (s *SharedService) ListEventsPages(ctx context.Context, opts ...client.RequestOption) iter.Seq2[*ListEvents200Response, error] {
+	return func(yield func(*ListEvents200Response, error) bool) {
+		pageOpts := opts
+		for {
+			page, err := s.ListEvents(ctx, pageOpts...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(page, nil) {
+				return
+			}
+
+			next, ok := page.Next.Get()
+			if !ok || next == "" {
+				return
+			}
+			pageOpts = append(opts[:len(opts):len(opts)], client.WithURL(next))
+		}
+	}
+}
+
+// ListEventsAll returns an iterator over all the items of [SharedService.ListEvents],
+// requesting the pages as needed (see [SharedService.ListEventsPages]).
+func (s *SharedService) ListEventsAll(ctx context.Context, opts ...client.RequestOption) iter.Seq2[ListEvents200ResponseEvent, error] {
+	return func(yield func(ListEvents200ResponseEvent, error) bool) {
+		for page, err := range s.ListEventsPages(ctx, opts...) {
+			if err != nil {
+				var zero ListEvents200ResponseEvent
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Events {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
 	}
 }
 
 // GetAllEnumTypes: Get all enum types
-func (s *SharedService) GetAllEnumTypes(ctx context.Context) (*AllEnumTypes, error) {
+func (s *SharedService) GetAllEnumTypes(ctx context.Context, opts ...client.RequestOption) (*AllEnumTypes, error) {
 	path := fmt.Sprintf("/enums")
 
-	resp, err := s.c.Call(ctx, http.MethodGet, path)
+	opts = append([]client.RequestOption{client.WithAccept("application/json"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("error building request: %v", err)
+		return nil, fmt.Errorf("error building request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var v AllEnumTypes
-		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
-			return nil, fmt.Errorf("decode response: %s", err.Error())
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
 		}
 
 		return &v, nil
 	default:
-		return nil, fmt.Errorf("unexpected response %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		return nil, client.NewAPIError(resp, "", nil)
 	}
 }
 
 // GetDeprecated: Get deprecated
 //
 // Deprecated: Use other - non-deprecated - endpoint instead.
-func (s *SharedService) GetDeprecated(ctx context.Context, body GetDeprecatedBody, params GetDeprecatedParams) (*GetDeprecated200Response, error) {
+func (s *SharedService) GetDeprecated(ctx context.Context, body GetDeprecatedBody, params GetDeprecatedParams, opts ...client.RequestOption) (*GetDeprecated200Response, error) {
 	path := fmt.Sprintf("/deprecated")
 
-	resp, err := s.c.Call(ctx, http.MethodGet, path, client.WithJSONBody(body), client.WithQueryValues(params.QueryValues()))
+	opts = append([]client.RequestOption{client.WithJSONBody(body), client.WithAccept("application/json"), client.WithQueryValues(params.QueryValues()), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("error building request: %v", err)
+		return nil, fmt.Errorf("error building request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var v GetDeprecated200Response
-		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
-			return nil, fmt.Errorf("decode response: %s", err.Error())
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// ListCards: List cards
+func (s *SharedService) ListCards(ctx context.Context, params ListCardsParams, opts ...client.RequestOption) (*CardList, error) {
+	path := fmt.Sprintf("/cards")
+
+	opts = append([]client.RequestOption{client.WithAccept("application/json"), client.WithQueryValues(params.QueryValues()), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var v CardList
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// ListCardsPages returns an iterator over the pages of [SharedService.ListCards],
+// requesting the next page when the previous one is consumed.
+func (s *SharedService) ListCardsPages(ctx context.Context, params ListCardsParams, opts ...client.RequestOption) iter.Seq2[*CardList, error] {
+	return func(yield func(*CardList, error) bool) {
+		// the iterator can be ranged over repeatedly, each time starting with the first page
+		params := params
+		pageOpts := opts
+		for {
+			page, err := s.ListCards(ctx, params, pageOpts...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(page, nil) {
+				return
+			}
+
+			if page.NextCursor == nil {
+				return
+			}
+			next := *page.NextCursor
+			if next == "" {
+				return
+			}
+			params.Cursor = &next
+		}
+	}
+}
+
+// ListCardsAll returns an iterator over all the items of [SharedService.ListCards],
+// requesting the pages as needed (see [SharedService.ListCardsPages]).
+func (s *SharedService) ListCardsAll(ctx context.Context, params ListCardsParams, opts ...client.RequestOption) iter.Seq2[Card, error] {
+	return func(yield func(Card, error) bool) {
+		for page, err := range s.ListCardsPages(ctx, params, opts...) {
+			if err != nil {
+				var zero Card
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ListBankTransfers: List bank transfers
+func (s *SharedService) ListBankTransfers(ctx context.Context, params ListBankTransfersParams, opts ...client.RequestOption) (*ListBankTransfers200Response, error) {
+	path := fmt.Sprintf("/bank-transfers")
+
+	opts = append([]client.RequestOption{client.WithAccept("application/json"), client.WithQueryValues(params.QueryValues()), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var v ListBankTransfers200Response
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// ListBankTransfersPages returns an iterator over the pages of [SharedService.ListBankTransfers],
+// requesting the next page when the previous one is consumed.
+func (s *SharedService) ListBankTransfersPages(ctx context.Context, params ListBankTransfersParams, opts ...client.RequestOption) iter.Seq2[*ListBankTransfers200Response, error] {
+	return func(yield func(*ListBankTransfers200Response, error) bool) {
+		// the iterator can be ranged over repeatedly, each time starting with the first page
+		params := params
+		pageOpts := opts
+		for {
+			page, err := s.ListBankTransfers(ctx, params, pageOpts...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(page, nil) {
+				return
+			}
+
+			if len(page.Items) == 0 {
+				return
+			}
+			if params.Limit != nil && len(page.Items) < int(*params.Limit) {
+				return
+			}
+			offset := len(page.Items)
+			if params.Offset != nil {
+				offset += *params.Offset
+			}
+			params.Offset = &offset
+		}
+	}
+}
+
+// ListBankTransfersAll returns an iterator over all the items of [SharedService.ListBankTransfers],
+// requesting the pages as needed (see [SharedService.ListBankTransfersPages]).
+func (s *SharedService) ListBankTransfersAll(ctx context.Context, params ListBankTransfersParams, opts ...client.RequestOption) iter.Seq2[BankTransfer, error] {
+	return func(yield func(BankTransfer, error) bool) {
+		for page, err := range s.ListBankTransfersPages(ctx, params, opts...) {
+			if err != nil {
+				var zero BankTransfer
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GetAnyOf: Get anyOf
+func (s *SharedService) GetAnyOf(ctx context.Context, opts ...client.RequestOption) (*AnyOfTypes, error) {
+	path := fmt.Sprintf("/any-of")
+
+	opts = append([]client.RequestOption{client.WithAccept("application/json"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var v AnyOfTypes
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// GetAllOf: Get allOf
+func (s *SharedService) GetAllOf(ctx context.Context, params GetAllOfParams, opts ...client.RequestOption) (*StoredCard, error) {
+	path := fmt.Sprintf("/all-of")
+
+	opts = append([]client.RequestOption{client.WithAccept("application/json"), client.WithQueryValues(params.QueryValues()), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var v StoredCard
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		return &v, nil
+	default:
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// DownloadReceipt: Download receipt
+func (s *SharedService) DownloadReceipt(ctx context.Context, iD string, opts ...client.RequestOption) (*client.Binary, error) {
+	path := fmt.Sprintf("/receipts/%s/pdf", url.PathEscape(iD))
+
+	opts = append([]client.RequestOption{client.WithAccept("application/pdf", "application/json"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// the body is closed by the caller
+		return client.NewBinary(resp), nil
+	case http.StatusNotFound:
+		defer resp.Body.Close()
+		var apiErr Error
+		return nil, client.NewAPIError(resp, "Receipt not found.", &apiErr)
+	default:
+		defer resp.Body.Close()
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// GetPartnerOrder: Get partner order
+func (s *SharedService) GetPartnerOrder(ctx context.Context, iD string, opts ...client.RequestOption) (*PartnerOrder, error) {
+	path := fmt.Sprintf("/partner-orders/%s", url.PathEscape(iD))
+
+	opts = append([]client.RequestOption{client.WithAccept("application/json", "application/xml"), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var v PartnerOrder
+		if err := client.DecodeResponse(resp, &v); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
 		}
 
 		return &v, nil
 	default:
-		return nil, fmt.Errorf("unexpected response %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		return nil, client.NewAPIError(resp, "", nil)
+	}
+}
+
+// GetLabels: Get labels with label path parameters
+func (s *SharedService) GetLabels(ctx context.Context, ids []int, count int, opts ...client.RequestOption) error {
+	idsValues := make([]string, 0, len(ids))
+	for _, v := range ids {
+		idsValues = append(idsValues, url.PathEscape(strconv.Itoa(v)))
+	}
+
+	path := fmt.Sprintf("/labels/.%s/;count=%s", strings.Join(idsValues, ","), url.PathEscape(strconv.Itoa(count)))
+
+	opts = append([]client.RequestOption{client.WithOperationServer(client.Server{URL: "https://labels.example.com"}), client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	default:
+		return client.NewAPIError(resp, "", nil)
+	}
+}
+
+// GetMerchantReport: Get report with escaped and matrix path parameters
+func (s *SharedService) GetMerchantReport(ctx context.Context, merchantCode string, date datetime.Date, tags []string, opts ...client.RequestOption) error {
+	tagsValues := make([]string, 0, len(tags))
+	for _, v := range tags {
+		tagsValues = append(tagsValues, url.PathEscape(v))
+	}
+
+	path := fmt.Sprintf("/merchants/%s/reports/%s;tags=%s", url.PathEscape(merchantCode), url.PathEscape(date.String()), strings.Join(tagsValues, ";tags="))
+
+	opts = append([]client.RequestOption{client.WithSecurity(client.SecurityRequirement{"apiKey"}, client.SecurityRequirement{"oauth2"})}, opts...)
+	resp, err := s.c.Call(ctx, http.MethodGet, path, opts...)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	default:
+		return client.NewAPIError(resp, "", nil)
 	}
 }